@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -26,6 +27,9 @@ type Config struct {
 
 	// 浏览器配置
 	Browser BrowserConfig `yaml:"browser"`
+
+	// 搜索结果缓存配置
+	Cache CacheConfig `yaml:"cache"`
 }
 
 // ServerConfig 服务器配置
@@ -45,6 +49,75 @@ type CORSConfig struct {
 type SearchConfig struct {
 	DefaultEngine  string   `yaml:"default_engine"`
 	AllowedEngines []string `yaml:"allowed_engines"`
+	// ResolveRedirects 是否将百度、搜狗、Bing 的跳转链接解析为真实地址
+	ResolveRedirects bool `yaml:"resolve_redirects"`
+	// Fallbacks 备用引擎链：引擎出错或没有结果时依次尝试的引擎
+	Fallbacks map[string][]string `yaml:"fallbacks"`
+	// CircuitBreaker 引擎熔断配置
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"`
+	// RateLimits 各引擎的限流配置，键为引擎名，"default" 作用于未单独配置的引擎
+	RateLimits map[string]RateLimitConfig `yaml:"rate_limits"`
+	// DomainRules 对所有请求生效的域名规则
+	DomainRules DomainRulesConfig `yaml:"domain_rules"`
+	// Processors 结果处理器的执行顺序，可引用内置处理器和通过 engine.RegisterProcessor 注册的自定义处理器
+	Processors []string `yaml:"processors"`
+	// BM25 合并结果的 BM25 重排配置
+	BM25 BM25Config `yaml:"bm25"`
+	// AutoEngines auto 模式下各语言使用的引擎，"default" 用于未单独配置的语言
+	AutoEngines map[string][]string `yaml:"auto_engines"`
+	// EngineTimeoutsMS 各引擎单次搜索的最长时间（毫秒），"default" 作用于未单独配置的引擎
+	EngineTimeoutsMS map[string]int `yaml:"engine_timeouts_ms"`
+	// Hedge 对冲请求配置
+	Hedge HedgeConfig `yaml:"hedge"`
+}
+
+// HedgeConfig 对冲请求配置：主引擎超过其 p95 延迟仍未返回时同时启动第一个备用引擎，取先返回结果的一个
+type HedgeConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// MinSamples 计算 p95 延迟所需的最少成功次数，样本不足时不对冲
+	MinSamples int `yaml:"min_samples"`
+	// MinDelayMS 启动备用引擎前的最短等待时间（毫秒），避免 p95 很低时频繁对冲
+	MinDelayMS int `yaml:"min_delay_ms"`
+}
+
+// BM25Config BM25 重排配置
+type BM25Config struct {
+	// Enabled 启用后未在 processors 中列出的 bm25 处理器会追加到流水线末尾
+	Enabled bool `yaml:"enabled"`
+	// BM25Weight 标题和描述相对查询的 BM25 得分权重
+	BM25Weight float64 `yaml:"bm25_weight"`
+	// RankWeight 引擎排名（融合得分）的权重
+	RankWeight float64 `yaml:"rank_weight"`
+}
+
+// DomainRulesConfig 域名规则配置
+// example.com 匹配该域名及其子域名，*.example.com 只匹配子域名
+type DomainRulesConfig struct {
+	// Block 屏蔽的域名
+	Block []string `yaml:"block"`
+	// Allow 非空时只保留这些域名的结果
+	Allow []string `yaml:"allow"`
+	// Boost 排名权重，大于 1 提升、小于 1 降低
+	Boost map[string]float64 `yaml:"boost"`
+}
+
+// RateLimitConfig 令牌桶限流配置
+type RateLimitConfig struct {
+	// RequestsPerSecond 每秒允许的请求数，0 表示不限流
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	// Burst 允许的突发请求数
+	Burst int `yaml:"burst"`
+}
+
+// CircuitBreakerConfig 引擎熔断配置
+type CircuitBreakerConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// FailureThreshold 连续失败多少次后熔断（遇到验证码立即熔断）
+	FailureThreshold int `yaml:"failure_threshold"`
+	// CooldownSeconds 首次熔断的冷却时间，之后每次再熔断翻倍
+	CooldownSeconds int `yaml:"cooldown_seconds"`
+	// MaxCooldownSeconds 冷却时间上限
+	MaxCooldownSeconds int `yaml:"max_cooldown_seconds"`
 }
 
 // ProxyConfig 代理配置
@@ -75,8 +148,50 @@ type BrowserConfig struct {
 	Headless bool `yaml:"headless"`
 }
 
+// defaultRateLimits 未配置时的内置限流，容易触发验证码的引擎限制得更严
+var defaultRateLimits = map[string]RateLimitConfig{
+	"baidu":          {RequestsPerSecond: 2, Burst: 1},
+	"sogou":          {RequestsPerSecond: 3, Burst: 1},
+	"sogou_weixin":   {RequestsPerSecond: 3, Burst: 1},
+	"duckduckgo":     {RequestsPerSecond: 2, Burst: 1},
+	"browser_baidu":  {RequestsPerSecond: 1, Burst: 1},
+	"browser_bing":   {RequestsPerSecond: 1, Burst: 1},
+	"browser_google": {RequestsPerSecond: 1, Burst: 1},
+}
+
+// defaultEngineTimeoutsMS 未配置时的内置超时（毫秒），浏览器引擎需要启动标签页和渲染页面
+var defaultEngineTimeoutsMS = map[string]int{
+	"default":        30000,
+	"browser_baidu":  60000,
+	"browser_bing":   60000,
+	"browser_google": 60000,
+}
+
+// CacheConfig 搜索结果缓存配置
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Backend 缓存后端：memory（内存 LRU）或 disk（磁盘持久化，重启后仍有效）
+	Backend string `yaml:"backend"`
+	// Dir 磁盘缓存目录
+	Dir        string `yaml:"dir"`
+	TTLSeconds int    `yaml:"ttl_seconds"`
+	MaxEntries int    `yaml:"max_entries"`
+}
+
+// AutoEngine 按查询语言自动选择引擎的特殊引擎名
+const AutoEngine = "auto"
+
+// defaultAutoEngines auto 模式的内置语言与引擎对应关系
+var defaultAutoEngines = map[string][]string{
+	"zh":      {"baidu", "sogou"},
+	"ja":      {"bing", "duckduckgo"},
+	"ko":      {"bing", "duckduckgo"},
+	"ru":      {"bing", "duckduckgo"},
+	"default": {"bing", "duckduckgo"},
+}
+
 // ValidEngines 有效的搜索引擎列表
-var ValidEngines = []string{"bing", "baidu", "duckduckgo", "google", "sogou", "browser_bing", "browser_baidu", "browser_google"}
+var ValidEngines = []string{"bing", "baidu", "duckduckgo", "google", "sogou", "sogou_weixin", "browser_bing", "browser_baidu", "browser_google", "arxiv", "crossref", "semantic_scholar", "hackernews", "reddit"}
 
 // DefaultConfig 默认配置
 var DefaultConfig = &Config{
@@ -89,8 +204,26 @@ var DefaultConfig = &Config{
 		},
 	},
 	Search: SearchConfig{
-		DefaultEngine:  "duckduckgo",
-		AllowedEngines: []string{},
+		DefaultEngine:    "duckduckgo",
+		AllowedEngines:   []string{},
+		ResolveRedirects: true,
+		Processors:       []string{"normalize", "filter", "enrich", "dedupe", "rerank"},
+		BM25: BM25Config{
+			Enabled:    false,
+			BM25Weight: 0.5,
+			RankWeight: 0.5,
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			Enabled:            true,
+			FailureThreshold:   3,
+			CooldownSeconds:    60,
+			MaxCooldownSeconds: 1800,
+		},
+		Hedge: HedgeConfig{
+			Enabled:    false,
+			MinSamples: 20,
+			MinDelayMS: 500,
+		},
 	},
 	Proxy: ProxyConfig{
 		Enabled: false,
@@ -101,13 +234,20 @@ var DefaultConfig = &Config{
 		ServerVersion: "1.0.0",
 		Tools: MCPToolsConfig{
 			SearchName:        "search",
-			SearchDescription: "Search the web using multiple engines (e.g., Bing, Baidu, DuckDuckGo) with no API key required. Returns structured results with title, URL, description, and source.",
+			SearchDescription: "Search the web using multiple engines with no API key required. Returns structured results with title, URL, description, and source.",
 		},
 	},
 	Browser: BrowserConfig{
 		Enabled:  true,
 		Headless: true,
 	},
+	Cache: CacheConfig{
+		Enabled:    true,
+		Backend:    "memory",
+		Dir:        ".cache/search",
+		TTLSeconds: 600,
+		MaxEntries: 1000,
+	},
 }
 
 // configSearchPaths 配置文件搜索路径
@@ -235,7 +375,7 @@ func (c *Config) validate() {
 	}
 
 	// 验证默认搜索引擎
-	if !isValidEngine(c.Search.DefaultEngine) {
+	if c.Search.DefaultEngine != AutoEngine && !isValidEngine(c.Search.DefaultEngine) {
 		log.Printf("⚠️ Invalid default_engine: %s, falling back to %s", c.Search.DefaultEngine, DefaultConfig.Search.DefaultEngine)
 		c.Search.DefaultEngine = DefaultConfig.Search.DefaultEngine
 	}
@@ -253,11 +393,143 @@ func (c *Config) validate() {
 	c.Search.AllowedEngines = validAllowed
 
 	// 如果设置了允许列表，检查默认引擎是否在列表中
-	if len(c.Search.AllowedEngines) > 0 && !contains(c.Search.AllowedEngines, c.Search.DefaultEngine) {
+	if len(c.Search.AllowedEngines) > 0 && c.Search.DefaultEngine != AutoEngine && !contains(c.Search.AllowedEngines, c.Search.DefaultEngine) {
 		log.Printf("⚠️ Default engine %s not in allowed list, using %s", c.Search.DefaultEngine, c.Search.AllowedEngines[0])
 		c.Search.DefaultEngine = c.Search.AllowedEngines[0]
 	}
 
+	// 验证 auto 模式的语言与引擎对应关系
+	validAuto := make(map[string][]string)
+	for lang, engines := range c.Search.AutoEngines {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		var valid []string
+		for _, e := range engines {
+			e = strings.TrimSpace(e)
+			if isValidEngine(e) && !contains(valid, e) {
+				valid = append(valid, e)
+			} else {
+				log.Printf("⚠️ Invalid auto engine for %s ignored: %s", lang, e)
+			}
+		}
+		if lang != "" && len(valid) > 0 {
+			validAuto[lang] = valid
+		}
+	}
+	c.Search.AutoEngines = validAuto
+
+	// 验证备用引擎链，忽略无效引擎和指向自身的备用项
+	validFallbacks := make(map[string][]string)
+	for engine, chain := range c.Search.Fallbacks {
+		engine = strings.TrimSpace(engine)
+		if !isValidEngine(engine) {
+			log.Printf("⚠️ Invalid fallback engine ignored: %s", engine)
+			continue
+		}
+		var valid []string
+		for _, e := range chain {
+			e = strings.TrimSpace(e)
+			if !isValidEngine(e) || e == engine || contains(valid, e) {
+				log.Printf("⚠️ Invalid fallback for %s ignored: %s", engine, e)
+				continue
+			}
+			valid = append(valid, e)
+		}
+		if len(valid) > 0 {
+			validFallbacks[engine] = valid
+		}
+	}
+	c.Search.Fallbacks = validFallbacks
+
+	// 验证熔断配置
+	if c.Search.CircuitBreaker.FailureThreshold <= 0 {
+		c.Search.CircuitBreaker.FailureThreshold = DefaultConfig.Search.CircuitBreaker.FailureThreshold
+	}
+	if c.Search.CircuitBreaker.CooldownSeconds <= 0 {
+		c.Search.CircuitBreaker.CooldownSeconds = DefaultConfig.Search.CircuitBreaker.CooldownSeconds
+	}
+	if c.Search.CircuitBreaker.MaxCooldownSeconds < c.Search.CircuitBreaker.CooldownSeconds {
+		log.Printf("⚠️ max_cooldown_seconds %d is less than cooldown_seconds, using %d", c.Search.CircuitBreaker.MaxCooldownSeconds, c.Search.CircuitBreaker.CooldownSeconds)
+		c.Search.CircuitBreaker.MaxCooldownSeconds = c.Search.CircuitBreaker.CooldownSeconds
+	}
+
+	// 验证限流配置
+	for engine, limit := range c.Search.RateLimits {
+		if engine != "default" && !isValidEngine(engine) {
+			log.Printf("⚠️ Invalid rate limit engine ignored: %s", engine)
+			delete(c.Search.RateLimits, engine)
+			continue
+		}
+		if limit.RequestsPerSecond < 0 {
+			limit.RequestsPerSecond = 0
+		}
+		if limit.Burst < 1 {
+			limit.Burst = 1
+		}
+		c.Search.RateLimits[engine] = limit
+	}
+
+	// 验证引擎超时配置
+	for engine, timeout := range c.Search.EngineTimeoutsMS {
+		if engine != "default" && !isValidEngine(engine) {
+			log.Printf("⚠️ Invalid engine timeout ignored: %s", engine)
+			delete(c.Search.EngineTimeoutsMS, engine)
+			continue
+		}
+		if timeout <= 0 {
+			log.Printf("⚠️ Invalid timeout %d ms for %s ignored", timeout, engine)
+			delete(c.Search.EngineTimeoutsMS, engine)
+		}
+	}
+
+	// 验证对冲请求配置
+	if c.Search.Hedge.MinSamples <= 0 {
+		c.Search.Hedge.MinSamples = DefaultConfig.Search.Hedge.MinSamples
+	}
+	if c.Search.Hedge.MinDelayMS < 0 {
+		c.Search.Hedge.MinDelayMS = DefaultConfig.Search.Hedge.MinDelayMS
+	}
+
+	// 验证结果处理器列表（未注册的名称在创建流水线时忽略）
+	validProcessors := []string{}
+	for _, p := range c.Search.Processors {
+		p = strings.TrimSpace(p)
+		if p != "" && !contains(validProcessors, p) {
+			validProcessors = append(validProcessors, p)
+		}
+	}
+	c.Search.Processors = validProcessors
+
+	// 验证 BM25 权重
+	if c.Search.BM25.BM25Weight < 0 || c.Search.BM25.RankWeight < 0 ||
+		c.Search.BM25.BM25Weight+c.Search.BM25.RankWeight == 0 {
+		log.Printf("⚠️ Invalid bm25 weights, using defaults")
+		c.Search.BM25.BM25Weight = DefaultConfig.Search.BM25.BM25Weight
+		c.Search.BM25.RankWeight = DefaultConfig.Search.BM25.RankWeight
+	}
+
+	// 验证域名权重
+	for pattern, weight := range c.Search.DomainRules.Boost {
+		if weight <= 0 {
+			log.Printf("⚠️ Invalid boost weight %v for %s ignored", weight, pattern)
+			delete(c.Search.DomainRules.Boost, pattern)
+		}
+	}
+
+	// 验证缓存配置
+	if c.Cache.Backend != "memory" && c.Cache.Backend != "disk" {
+		log.Printf("⚠️ Invalid cache backend: %s, using %s", c.Cache.Backend, DefaultConfig.Cache.Backend)
+		c.Cache.Backend = DefaultConfig.Cache.Backend
+	}
+	if c.Cache.Dir == "" {
+		c.Cache.Dir = DefaultConfig.Cache.Dir
+	}
+	if c.Cache.TTLSeconds <= 0 {
+		c.Cache.TTLSeconds = DefaultConfig.Cache.TTLSeconds
+	}
+	if c.Cache.MaxEntries <= 0 {
+		c.Cache.MaxEntries = DefaultConfig.Cache.MaxEntries
+	}
+
 	// 验证代理 URL
 	if c.Proxy.Enabled && c.Proxy.URL == "" {
 		log.Printf("⚠️ Proxy enabled but URL is empty, using default")
@@ -287,6 +559,9 @@ func (c *Config) Print() {
 	} else {
 		log.Printf("🔍 No search engine restrictions, all available engines can be used")
 	}
+	for engine, chain := range c.Search.Fallbacks {
+		log.Printf("↪️ Fallback chain: %s -> %s", engine, strings.Join(chain, " -> "))
+	}
 	if c.Proxy.Enabled {
 		log.Printf("🌐 Using proxy: %s", c.Proxy.URL)
 	} else {
@@ -297,6 +572,11 @@ func (c *Config) Print() {
 	} else {
 		log.Printf("🔒 CORS disabled")
 	}
+	if c.Cache.Enabled {
+		log.Printf("💾 Result cache: %s, ttl=%ds, max_entries=%d", c.Cache.Backend, c.Cache.TTLSeconds, c.Cache.MaxEntries)
+	} else {
+		log.Printf("💾 Result cache disabled")
+	}
 	log.Printf("🔧 MCP Server: %s v%s", c.MCP.ServerName, c.MCP.ServerVersion)
 	log.Printf("🔧 MCP Search tool name: %s", c.MCP.Tools.SearchName)
 	log.Printf("🖥️ Server will listen on %s:%d", c.Server.Host, c.Server.Port)
@@ -367,6 +647,136 @@ func (c *Config) GetMCPSearchToolDescription() string {
 	return c.MCP.Tools.SearchDescription
 }
 
+// IsResolveRedirects 是否解析跳转链接
+func (c *Config) IsResolveRedirects() bool {
+	return c.Search.ResolveRedirects
+}
+
+// GetAutoEngines 获取 auto 模式下某语言使用的引擎，依次查找：配置中的该语言、配置中的 default、内置的该语言、内置的 default。
+// 只配置了 default 时所有未单独配置的语言都使用它，不会落到内置的语言对应关系
+func (c *Config) GetAutoEngines(lang string) []string {
+	if engines, ok := c.Search.AutoEngines[lang]; ok {
+		return engines
+	}
+	if engines, ok := c.Search.AutoEngines["default"]; ok {
+		return engines
+	}
+	if engines, ok := defaultAutoEngines[lang]; ok {
+		return engines
+	}
+	return defaultAutoEngines["default"]
+}
+
+// GetFallbacks 获取引擎的备用引擎链
+func (c *Config) GetFallbacks(engine string) []string {
+	return c.Search.Fallbacks[engine]
+}
+
+// IsCircuitBreakerEnabled 是否启用引擎熔断
+func (c *Config) IsCircuitBreakerEnabled() bool {
+	return c.Search.CircuitBreaker.Enabled
+}
+
+// GetCircuitBreakerThreshold 获取熔断前允许的连续失败次数
+func (c *Config) GetCircuitBreakerThreshold() int {
+	return c.Search.CircuitBreaker.FailureThreshold
+}
+
+// GetCircuitBreakerCooldown 获取首次熔断的冷却时间
+func (c *Config) GetCircuitBreakerCooldown() time.Duration {
+	return time.Duration(c.Search.CircuitBreaker.CooldownSeconds) * time.Second
+}
+
+// GetCircuitBreakerMaxCooldown 获取熔断冷却时间上限
+func (c *Config) GetCircuitBreakerMaxCooldown() time.Duration {
+	return time.Duration(c.Search.CircuitBreaker.MaxCooldownSeconds) * time.Second
+}
+
+// GetRateLimit 获取引擎的限流配置：优先使用引擎自己的配置，其次是 default，最后是内置默认值
+func (c *Config) GetRateLimit(engine string) RateLimitConfig {
+	if limit, ok := c.Search.RateLimits[engine]; ok {
+		return limit
+	}
+	if limit, ok := c.Search.RateLimits["default"]; ok {
+		return limit
+	}
+	return defaultRateLimits[engine]
+}
+
+// GetEngineTimeout 获取引擎单次搜索的最长时间：优先使用引擎自己的配置，其次是该引擎的内置值（如浏览器引擎），
+// 配置的 default 只作用于两者都没有的引擎
+func (c *Config) GetEngineTimeout(engine string) time.Duration {
+	timeout, ok := c.Search.EngineTimeoutsMS[engine]
+	if !ok {
+		timeout, ok = defaultEngineTimeoutsMS[engine]
+	}
+	if !ok {
+		timeout, ok = c.Search.EngineTimeoutsMS["default"]
+	}
+	if !ok {
+		timeout = defaultEngineTimeoutsMS["default"]
+	}
+	return time.Duration(timeout) * time.Millisecond
+}
+
+// IsHedgeEnabled 是否启用对冲请求
+func (c *Config) IsHedgeEnabled() bool {
+	return c.Search.Hedge.Enabled
+}
+
+// GetHedgeMinSamples 获取计算 p95 延迟所需的最少样本数
+func (c *Config) GetHedgeMinSamples() int {
+	return c.Search.Hedge.MinSamples
+}
+
+// GetHedgeMinDelay 获取启动备用引擎前的最短等待时间
+func (c *Config) GetHedgeMinDelay() time.Duration {
+	return time.Duration(c.Search.Hedge.MinDelayMS) * time.Millisecond
+}
+
+// IsCacheEnabled 是否启用搜索结果缓存
+func (c *Config) IsCacheEnabled() bool {
+	return c.Cache.Enabled
+}
+
+// GetCacheBackend 获取缓存后端
+func (c *Config) GetCacheBackend() string {
+	return c.Cache.Backend
+}
+
+// GetCacheDir 获取磁盘缓存目录
+func (c *Config) GetCacheDir() string {
+	return c.Cache.Dir
+}
+
+// GetCacheTTL 获取缓存有效期
+func (c *Config) GetCacheTTL() time.Duration {
+	return time.Duration(c.Cache.TTLSeconds) * time.Second
+}
+
+// GetCacheMaxEntries 获取缓存最大条目数
+func (c *Config) GetCacheMaxEntries() int {
+	return c.Cache.MaxEntries
+}
+
+// GetDomainRules 获取全局域名规则
+func (c *Config) GetDomainRules() DomainRulesConfig {
+	return c.Search.DomainRules
+}
+
+// GetProcessors 获取结果处理器的执行顺序，启用 BM25 重排时确保包含 bm25
+func (c *Config) GetProcessors() []string {
+	if c.Search.BM25.Enabled && !contains(c.Search.Processors, "bm25") {
+		return append(append([]string{}, c.Search.Processors...), "bm25")
+	}
+	return c.Search.Processors
+}
+
+// GetBM25Weights 获取 BM25 得分和引擎排名的混合权重
+func (c *Config) GetBM25Weights() (float64, float64) {
+	return c.Search.BM25.BM25Weight, c.Search.BM25.RankWeight
+}
+
 // IsBrowserEnabled 是否启用浏览器引擎
 func (c *Config) IsBrowserEnabled() bool {
 	return c.Browser.Enabled
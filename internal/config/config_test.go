@@ -0,0 +1,52 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetAutoEngines(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured map[string][]string
+		lang       string
+		want       []string
+	}{
+		{"builtin language", nil, "zh", []string{"baidu", "sogou"}},
+		{"builtin default", nil, "fr", []string{"bing", "duckduckgo"}},
+		{"configured language", map[string][]string{"ja": {"browser_google"}}, "ja", []string{"browser_google"}},
+		// 只配置了 default 时优先于内置的语言对应关系
+		{"configured default before builtin language", map[string][]string{"default": {"duckduckgo"}}, "zh", []string{"duckduckgo"}},
+		{"configured default", map[string][]string{"default": {"duckduckgo"}}, "ko", []string{"duckduckgo"}},
+	}
+	for _, tt := range tests {
+		cfg := &Config{Search: SearchConfig{AutoEngines: tt.configured}}
+		if got := cfg.GetAutoEngines(tt.lang); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: GetAutoEngines(%s) = %v, want %v", tt.name, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestGetEngineTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured map[string]int
+		engine     string
+		want       time.Duration
+	}{
+		{"builtin default", nil, "bing", 30 * time.Second},
+		{"builtin engine", nil, "browser_google", 60 * time.Second},
+		{"configured engine", map[string]int{"bing": 5000}, "bing", 5 * time.Second},
+		{"configured default", map[string]int{"default": 10000}, "bing", 10 * time.Second},
+		// 配置的 default 不会缩短浏览器引擎的内置超时
+		{"configured default keeps builtin engine", map[string]int{"default": 10000}, "browser_google", 60 * time.Second},
+		{"configured engine before builtin engine", map[string]int{"browser_google": 45000}, "browser_google", 45 * time.Second},
+	}
+	for _, tt := range tests {
+		cfg := &Config{Search: SearchConfig{EngineTimeoutsMS: tt.configured}}
+		if got := cfg.GetEngineTimeout(tt.engine); got != tt.want {
+			t.Errorf("%s: GetEngineTimeout(%s) = %v, want %v", tt.name, tt.engine, got, tt.want)
+		}
+	}
+}
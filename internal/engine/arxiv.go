@@ -0,0 +1,207 @@
+package engine
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ArxivEngine arXiv 论文搜索引擎实现（基于 Atom API）
+type ArxivEngine struct {
+	client   *http.Client
+	proxyURL string
+}
+
+// NewArxivEngine 创建 arXiv 搜索引擎实例
+func NewArxivEngine(proxyURL string, limiter *RateLimiter) *ArxivEngine {
+	transport := &http.Transport{}
+	if proxyURL != "" {
+		if proxy, err := url.Parse(proxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+
+	client := &http.Client{
+		Transport: newRateLimitedTransport(limiter, transport),
+	}
+
+	return &ArxivEngine{
+		client:   client,
+		proxyURL: proxyURL,
+	}
+}
+
+// Name 返回引擎名称
+func (e *ArxivEngine) Name() string {
+	return "arxiv"
+}
+
+// Capabilities 返回引擎能力描述
+func (e *ArxivEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryAcademic,
+		Description: "arXiv preprints",
+		Verticals:   webOnly,
+		Filters:     []string{FilterFreshness, FilterDateRange, FilterSort},
+		MaxResults:  100,
+		Pagination:  true,
+		RegionFocus: RegionGlobal,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符（查询会被拆成 all: 字段条件，操作符全部后置过滤）
+func (e *ArxivEngine) QueryDialect() QueryDialect {
+	return QueryDialect{}
+}
+
+// arxivFeed arXiv Atom 响应
+type arxivFeed struct {
+	Entries []arxivEntry `xml:"entry"`
+}
+
+// arxivEntry arXiv Atom 条目
+type arxivEntry struct {
+	ID         string `xml:"id"`
+	Title      string `xml:"title"`
+	Summary    string `xml:"summary"`
+	Published  string `xml:"published"`
+	DOI        string `xml:"doi"`
+	JournalRef string `xml:"journal_ref"`
+	Authors    []struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Links []struct {
+		Href  string `xml:"href,attr"`
+		Rel   string `xml:"rel,attr"`
+		Title string `xml:"title,attr"`
+		Type  string `xml:"type,attr"`
+	} `xml:"link"`
+}
+
+// Search 执行 arXiv 搜索
+func (e *ArxivEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	// arXiv 单次最多返回 2000 条，这里限制在 100 以内
+	maxResults := limit
+	if maxResults > 100 {
+		maxResults = 100
+	}
+
+	params := url.Values{}
+	searchQuery := e.buildSearchQuery(query)
+	if from, to, ok := opts.timeRange(time.Now()); ok {
+		searchQuery += fmt.Sprintf(" AND submittedDate:[%s TO %s]", from.UTC().Format("200601021504"), to.UTC().Format("200601021504"))
+	}
+	params.Set("search_query", searchQuery)
+	params.Set("start", fmt.Sprintf("%d", opts.Offset))
+	params.Set("max_results", fmt.Sprintf("%d", maxResults))
+	if opts.Sort == SortDate {
+		params.Set("sortBy", "submittedDate")
+		params.Set("sortOrder", "descending")
+	} else {
+		params.Set("sortBy", "relevance")
+	}
+
+	searchURL := fmt.Sprintf("https://export.arxiv.org/api/query?%s", params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	req.Header.Set("User-Agent", "go-web-search-mcp/1.0")
+	req.Header.Set("Accept", "application/atom+xml")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	var feed arxivFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("parse Atom feed failed: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(feed.Entries))
+	for _, entry := range feed.Entries {
+		if result := e.parseEntry(entry); result != nil {
+			results = append(results, *result)
+		}
+	}
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	log.Printf("🔍 arXiv: found %d results for query '%s'", len(results), query)
+	return results, nil
+}
+
+// buildSearchQuery 将普通关键词转换为 arXiv 查询语法（所有词 AND 连接）
+func (e *ArxivEngine) buildSearchQuery(query string) string {
+	words := strings.Fields(query)
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, "all:"+w)
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// parseEntry 解析单个 Atom 条目
+func (e *ArxivEngine) parseEntry(entry arxivEntry) *SearchResult {
+	title := cleanText(entry.Title)
+	if title == "" || entry.ID == "" {
+		return nil
+	}
+
+	authors := make([]string, 0, len(entry.Authors))
+	for _, a := range entry.Authors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			authors = append(authors, name)
+		}
+	}
+
+	absURL := strings.TrimSpace(entry.ID)
+	pdfURL := ""
+	for _, link := range entry.Links {
+		if link.Rel == "alternate" && link.Href != "" {
+			absURL = link.Href
+		}
+		if link.Title == "pdf" || link.Type == "application/pdf" {
+			pdfURL = link.Href
+		}
+	}
+
+	venue := cleanText(entry.JournalRef)
+	if venue == "" {
+		venue = "arXiv"
+	}
+
+	year := 0
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(entry.Published)); err == nil {
+		year = t.Year()
+	}
+
+	return &SearchResult{
+		Title:       title,
+		URL:         absURL,
+		Description: truncateText(cleanText(entry.Summary), 500),
+		Source:      "arxiv.org",
+		Engine:      "arxiv",
+		Authors:     authors,
+		Venue:       venue,
+		Year:        year,
+		DOI:         strings.TrimSpace(entry.DOI),
+		PDFURL:      pdfURL,
+	}
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestArxivSearch(t *testing.T) {
+	var query string
+	e := &ArxivEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		query = req.URL.RawQuery
+		return http.StatusOK, readFixture(t, "arxiv.xml")
+	})}
+
+	results, err := e.Search(context.Background(), "attention model", 10, SearchOptions{Offset: 20, Sort: SortDate})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := queryParams(t, query)
+	if got := params.Get("search_query"); got != "all:attention AND all:model" {
+		t.Errorf("search_query = %q", got)
+	}
+	if params.Get("start") != "20" || params.Get("sortBy") != "submittedDate" {
+		t.Errorf("request params = %v", params)
+	}
+
+	// 标题为空的条目被跳过
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	want := SearchResult{
+		Title:       "Attention Is All You Need",
+		URL:         "http://arxiv.org/abs/1706.03762v7",
+		Description: "The dominant sequence transduction models are based on complex recurrent or convolutional neural networks.",
+		Source:      "arxiv.org",
+		Engine:      "arxiv",
+		Authors:     []string{"Ashish Vaswani", "Noam Shazeer"},
+		Venue:       "Advances in Neural Information Processing Systems 30 (2017)",
+		Year:        2017,
+		DOI:         "10.48550/arXiv.1706.03762",
+		PDFURL:      "http://arxiv.org/pdf/1706.03762v7",
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+
+	// 没有期刊信息时 venue 为 arXiv
+	if r := results[1]; r.Venue != "arXiv" || r.PDFURL != "" || r.URL != "http://arxiv.org/abs/2005.14165v4" {
+		t.Errorf("results[1] = %+v", r)
+	}
+}
+
+func TestArxivSearchError(t *testing.T) {
+	e := &ArxivEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		return http.StatusServiceUnavailable, "Rate exceeded."
+	})}
+	if _, err := e.Search(context.Background(), "attention", 10, SearchOptions{}); err == nil {
+		t.Error("Search() with status 503 returned no error")
+	}
+}
@@ -21,7 +21,7 @@ type BaiduEngine struct {
 }
 
 // NewBaiduEngine 创建百度搜索引擎实例
-func NewBaiduEngine(proxyURL string) *BaiduEngine {
+func NewBaiduEngine(proxyURL string, limiter *RateLimiter) *BaiduEngine {
 	jar, _ := cookiejar.New(nil)
 
 	transport := &http.Transport{}
@@ -32,9 +32,8 @@ func NewBaiduEngine(proxyURL string) *BaiduEngine {
 	}
 
 	client := &http.Client{
-		Timeout:   30 * time.Second,
 		Jar:       jar,
-		Transport: transport,
+		Transport: newRateLimitedTransport(limiter, transport),
 	}
 
 	return &BaiduEngine{
@@ -48,18 +47,51 @@ func (e *BaiduEngine) Name() string {
 	return "baidu"
 }
 
+// Capabilities 返回引擎能力描述
+func (e *BaiduEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryWeb,
+		Description: "Baidu, best coverage of Chinese content",
+		Verticals:   SearchTypes,
+		Filters:     []string{FilterFreshness, FilterDateRange},
+		MaxResults:  50,
+		Pagination:  true,
+		RegionFocus: RegionCN,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符（百度不支持 -site:，OR 使用 |）
+func (e *BaiduEngine) QueryDialect() QueryDialect {
+	return QueryDialect{Site: true, FileType: true, InTitle: true, Phrase: true, Exclude: true, OR: "|"}
+}
+
 // Search 执行百度搜索
-func (e *BaiduEngine) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+func (e *BaiduEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
 	// 首先访问百度主页获取 cookie
 	if err := e.warmup(ctx); err != nil {
 		log.Printf("⚠️ Baidu warmup failed: %v", err)
 	}
 
+	// 根据搜索类型选择分页抓取函数
+	fetchPage := e.searchPage
+	switch opts.Type {
+	case SearchTypeNews:
+		fetchPage = e.searchNewsPage
+	case SearchTypeImages:
+		fetchPage = e.searchImagesPage
+	case SearchTypeVideos:
+		fetchPage = e.searchVideosPage
+	}
+
+	// 百度每页10条结果，pn 为结果偏移
+	page, skip := pageWindow(opts.Offset, 10)
+	startPn := page * 10
+	pn := startPn
+
 	var allResults []SearchResult
-	pn := 0
 
-	for len(allResults) < limit {
-		results, err := e.searchPage(ctx, query, pn)
+	for len(allResults) < skip+limit {
+		results, err := fetchPage(ctx, query, pn, opts)
 		if err != nil {
 			// 检查是否是验证码限制错误
 			if strings.Contains(err.Error(), "captcha") || strings.Contains(err.Error(), "rate limited") {
@@ -84,21 +116,12 @@ func (e *BaiduEngine) Search(ctx context.Context, query string, limit int) ([]Se
 		pn += 10 // 百度每页10条结果
 
 		// 限制最多搜索5页
-		if pn > 40 {
+		if pn > startPn+40 {
 			break
 		}
-
-		// 添加延迟避免触发限制
-		if pn < 40 && len(allResults) < limit {
-			time.Sleep(500 * time.Millisecond)
-		}
-	}
-
-	if len(allResults) > limit {
-		allResults = allResults[:limit]
 	}
 
-	return allResults, nil
+	return window(allResults, skip, limit), nil
 }
 
 // warmup 访问百度主页获取初始 cookie
@@ -124,7 +147,7 @@ func (e *BaiduEngine) warmup(ctx context.Context) error {
 }
 
 // searchPage 搜索单页结果
-func (e *BaiduEngine) searchPage(ctx context.Context, query string, pn int) ([]SearchResult, error) {
+func (e *BaiduEngine) searchPage(ctx context.Context, query string, pn int, opts SearchOptions) ([]SearchResult, error) {
 	// 构建请求 URL - 使用更完整的参数来模拟真实浏览器请求
 	params := url.Values{}
 	params.Set("wd", query)
@@ -145,6 +168,9 @@ func (e *BaiduEngine) searchPage(ctx context.Context, query string, pn int) ([]S
 	params.Set("f4s", "1")
 	params.Set("csor", "5")
 	params.Set("_cr1", "30385")
+	if gpc := baiduTimeFilter(opts, time.Now()); gpc != "" {
+		params.Set("gpc", gpc)
+	}
 
 	searchURL := fmt.Sprintf("https://www.baidu.com/s?%s", params.Encode())
 
@@ -180,7 +206,7 @@ func (e *BaiduEngine) searchPage(ctx context.Context, query string, pn int) ([]S
 		strings.Contains(bodyStr, "百度安全验证") ||
 		strings.Contains(bodyStr, "安全验证") {
 		log.Printf("⚠️ Baidu: Detected captcha/verification page, trying mobile approach")
-		mobileResults, err := e.searchPageMobile(ctx, query, pn)
+		mobileResults, err := e.searchPageMobile(ctx, query, pn, opts)
 		if err != nil {
 			return nil, fmt.Errorf("baidu rate limited/captcha required: %w", err)
 		}
@@ -206,6 +232,15 @@ func (e *BaiduEngine) searchPage(ctx context.Context, query string, pn int) ([]S
 	return results, nil
 }
 
+// baiduTimeFilter 生成时间过滤参数 gpc=stf=起始,结束|stftype=1（Unix 秒）
+func baiduTimeFilter(opts SearchOptions, now time.Time) string {
+	from, to, ok := opts.timeRange(now)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("stf=%d,%d|stftype=1", from.Unix(), to.Unix())
+}
+
 // setHeaders 设置请求头
 func (e *BaiduEngine) setHeaders(req *http.Request) {
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
@@ -359,12 +394,15 @@ func (e *BaiduEngine) isInternalLink(href, title string) bool {
 	return false
 }
 
-// searchPageMobile 使用移动端页面搜索（备选方案）
-func (e *BaiduEngine) searchPageMobile(ctx context.Context, query string, pn int) ([]SearchResult, error) {
+// searchPageMobile 使用移动端页面搜索（备选方案），时间过滤与桌面版使用相同的 gpc 参数
+func (e *BaiduEngine) searchPageMobile(ctx context.Context, query string, pn int, opts SearchOptions) ([]SearchResult, error) {
 	// 使用移动端 URL
 	params := url.Values{}
 	params.Set("word", query)
 	params.Set("pn", fmt.Sprintf("%d", pn))
+	if gpc := baiduTimeFilter(opts, time.Now()); gpc != "" {
+		params.Set("gpc", gpc)
+	}
 
 	searchURL := fmt.Sprintf("https://m.baidu.com/s?%s", params.Encode())
 
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// baiduCaptchaTransport 桌面版返回安全验证页，记录移动版请求的地址
+type baiduCaptchaTransport struct {
+	mu     sync.Mutex
+	mobile []string
+}
+
+func (t *baiduCaptchaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := "<html></html>"
+	switch {
+	case req.URL.Host == "m.baidu.com":
+		t.mu.Lock()
+		t.mobile = append(t.mobile, req.URL.String())
+		t.mu.Unlock()
+	case req.URL.Path == "/s":
+		body = "<html>百度安全验证</html>"
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// 桌面版触发验证码后回退到移动版时保留时间过滤
+func TestBaiduMobileFallbackKeepsTimeFilter(t *testing.T) {
+	transport := &baiduCaptchaTransport{}
+	e := &BaiduEngine{client: &http.Client{Transport: transport}}
+
+	e.Search(context.Background(), "golang", 10, SearchOptions{Freshness: FreshnessWeek})
+
+	if len(transport.mobile) == 0 {
+		t.Fatal("mobile page was not requested")
+	}
+	if !strings.Contains(transport.mobile[0], "gpc=stf") {
+		t.Errorf("mobile request %s has no gpc time filter", transport.mobile[0])
+	}
+}
+
+func TestBaiduTimeFilter(t *testing.T) {
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		opts SearchOptions
+		want string
+	}{
+		{SearchOptions{}, ""},
+		{SearchOptions{Freshness: FreshnessDay}, "stf=1768348800,1768435200|stftype=1"},
+		{SearchOptions{Freshness: FreshnessCustom, DateFrom: "2026-01-01", DateTo: "2026-01-02"}, "stf=1767225600,1767398399|stftype=1"},
+	}
+	for _, tt := range tests {
+		if got := baiduTimeFilter(tt.opts, now); got != tt.want {
+			t.Errorf("baiduTimeFilter(%+v) = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// fetchBody 请求百度页面并返回响应内容，检测验证码页面
+func (e *BaiduEngine) fetchBody(ctx context.Context, searchURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create request failed: %w", err)
+	}
+
+	e.setHeaders(req)
+	// 垂直搜索页面不需要压缩头，交由 Transport 自动处理
+	req.Header.Del("Accept-Encoding")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read body failed: %w", err)
+	}
+
+	bodyStr := string(body)
+	if strings.Contains(resp.Request.URL.Host, "wappass.baidu.com") ||
+		strings.Contains(bodyStr, "百度安全验证") {
+		return "", fmt.Errorf("baidu rate limited: captcha required")
+	}
+
+	return bodyStr, nil
+}
+
+// searchNewsPage 搜索单页新闻结果
+func (e *BaiduEngine) searchNewsPage(ctx context.Context, query string, pn int, opts SearchOptions) ([]SearchResult, error) {
+	params := url.Values{}
+	params.Set("tn", "news")
+	params.Set("rtt", "1") // 1 按焦点排序，4 按时间排序
+	params.Set("bsst", "1")
+	params.Set("cl", "2")
+	params.Set("wd", query)
+	params.Set("pn", fmt.Sprintf("%d", pn))
+	if gpc := baiduTimeFilter(opts, time.Now()); gpc != "" {
+		params.Set("gpc", gpc)
+	}
+
+	bodyStr, err := e.fetchBody(ctx, fmt.Sprintf("https://www.baidu.com/s?%s", params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(bodyStr))
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML failed: %w", err)
+	}
+
+	now := time.Now()
+	var results []SearchResult
+
+	doc.Find("#content_left div.result-op, #content_left div.result").Each(func(i int, s *goquery.Selection) {
+		linkEl := s.Find("h3 a").First()
+		if linkEl.Length() == 0 {
+			return
+		}
+
+		href, _ := linkEl.Attr("href")
+		title := strings.TrimSpace(linkEl.Text())
+		if label, ok := linkEl.Attr("aria-label"); ok && label != "" {
+			title = strings.TrimPrefix(label, "标题：")
+		}
+		if href == "" || title == "" || !strings.HasPrefix(href, "http") {
+			return
+		}
+
+		if e.isInternalLink(href, title) {
+			return
+		}
+
+		description := ""
+		if descEl := s.Find(".c-font-normal.c-color-text").First(); descEl.Length() > 0 {
+			if label, ok := descEl.Attr("aria-label"); ok && label != "" {
+				description = strings.TrimPrefix(label, "摘要：")
+			} else {
+				description = strings.TrimSpace(descEl.Text())
+			}
+		}
+
+		publisher := strings.TrimSpace(s.Find(".c-color-gray").First().Text())
+		publishedAt := parseDisplayTime(s.Find(".c-color-gray2").First().Text(), now)
+
+		thumbnail := ""
+		if img := s.Find("img").First(); img.Length() > 0 {
+			thumbnail, _ = img.Attr("src")
+		}
+
+		source := publisher
+		if source == "" {
+			if parsedURL, err := url.Parse(href); err == nil {
+				source = parsedURL.Host
+			}
+		}
+
+		results = append(results, SearchResult{
+			Title:       title,
+			URL:         href,
+			Description: truncateText(description, 500),
+			Source:      source,
+			Engine:      "baidu",
+			Thumbnail:   thumbnail,
+			Publisher:   publisher,
+			PublishedAt: publishedAt,
+		})
+	})
+
+	log.Printf("🔍 Baidu news page %d: found %d results", pn/10, len(results))
+	return results, nil
+}
+
+// baiduImageResponse 百度图片 acjson 接口响应
+type baiduImageResponse struct {
+	Data []struct {
+		ThumbURL         string `json:"thumbURL"`
+		MiddleURL        string `json:"middleURL"`
+		FromPageTitleEnc string `json:"fromPageTitleEnc"`
+		FromURLHost      string `json:"fromURLHost"`
+		ReplaceURL       []struct {
+			ObjURL  string `json:"ObjURL"`
+			FromURL string `json:"FromURL"`
+		} `json:"replaceUrl"`
+	} `json:"data"`
+}
+
+// searchImagesPage 搜索单页图片结果
+func (e *BaiduEngine) searchImagesPage(ctx context.Context, query string, pn int, opts SearchOptions) ([]SearchResult, error) {
+	params := url.Values{}
+	params.Set("tn", "resultjson_com")
+	params.Set("ipn", "rj")
+	params.Set("word", query)
+	params.Set("queryWord", query)
+	params.Set("ie", "utf-8")
+	params.Set("oe", "utf-8")
+	params.Set("pn", fmt.Sprintf("%d", pn))
+	params.Set("rn", "10")
+
+	bodyStr, err := e.fetchBody(ctx, fmt.Sprintf("https://image.baidu.com/search/acjson?%s", params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	// 接口返回的 JSON 中可能含有非法转义 \'
+	bodyStr = strings.ReplaceAll(bodyStr, `\'`, `'`)
+
+	var data baiduImageResponse
+	if err := json.Unmarshal([]byte(bodyStr), &data); err != nil {
+		return nil, fmt.Errorf("parse JSON failed: %w", err)
+	}
+
+	var results []SearchResult
+	for _, item := range data.Data {
+		if len(item.ReplaceURL) == 0 || item.ThumbURL == "" {
+			continue
+		}
+
+		pageURL := item.ReplaceURL[0].FromURL
+		mediaURL := item.ReplaceURL[0].ObjURL
+		if pageURL == "" {
+			pageURL = mediaURL
+		}
+		if pageURL == "" {
+			continue
+		}
+
+		results = append(results, SearchResult{
+			Title:     cleanText(item.FromPageTitleEnc),
+			URL:       pageURL,
+			Source:    item.FromURLHost,
+			Engine:    "baidu",
+			Thumbnail: item.ThumbURL,
+			MediaURL:  mediaURL,
+		})
+	}
+
+	log.Printf("🔍 Baidu images page %d: found %d results", pn/10, len(results))
+	return results, nil
+}
+
+// searchVideosPage 搜索单页视频结果
+func (e *BaiduEngine) searchVideosPage(ctx context.Context, query string, pn int, opts SearchOptions) ([]SearchResult, error) {
+	params := url.Values{}
+	params.Set("pd", "video")
+	params.Set("tn", "vsearch")
+	params.Set("wd", query)
+	params.Set("pn", fmt.Sprintf("%d", pn))
+
+	bodyStr, err := e.fetchBody(ctx, fmt.Sprintf("https://www.baidu.com/sf/vsearch?%s", params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(bodyStr))
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML failed: %w", err)
+	}
+
+	now := time.Now()
+	var results []SearchResult
+
+	doc.Find(".video_list .video_short, .video-item").Each(func(i int, s *goquery.Selection) {
+		linkEl := s.Find("a[href]").First()
+		if linkEl.Length() == 0 {
+			return
+		}
+
+		href, _ := linkEl.Attr("href")
+		title := strings.TrimSpace(s.Find(".video-title, .video_title").First().Text())
+		if title == "" {
+			title, _ = linkEl.Attr("title")
+		}
+		if href == "" || title == "" || !strings.HasPrefix(href, "http") {
+			return
+		}
+
+		thumbnail := ""
+		if img := s.Find("img").First(); img.Length() > 0 {
+			thumbnail, _ = img.Attr("src")
+		}
+
+		duration := strings.TrimSpace(s.Find(".video_play_timer, .time-length").First().Text())
+		publisher := strings.TrimSpace(s.Find(".wetSource, .video-source").First().Text())
+		publishedAt := parseDisplayTime(s.Find(".c-color-gray2, .video-time").First().Text(), now)
+
+		source := ""
+		if parsedURL, err := url.Parse(href); err == nil {
+			source = parsedURL.Host
+		}
+
+		results = append(results, SearchResult{
+			Title:       title,
+			URL:         href,
+			Source:      source,
+			Engine:      "baidu",
+			Thumbnail:   thumbnail,
+			MediaURL:    href,
+			Duration:    duration,
+			Publisher:   publisher,
+			PublishedAt: publishedAt,
+		})
+	})
+
+	// 视频页没有时间参数，按发布时间过滤
+	results = filterByPublishedTime(results, opts, now)
+
+	log.Printf("🔍 Baidu videos page %d: found %d results", pn/10, len(results))
+	return results, nil
+}
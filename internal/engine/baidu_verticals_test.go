@@ -0,0 +1,114 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// baiduVerticalClient 第一页返回样本，之后的页面为空
+func baiduVerticalClient(t *testing.T, path, fixture, empty string) *http.Client {
+	return fixtureClient(func(req *http.Request) (int, string) {
+		if req.URL.Path == path && req.URL.Query().Get("pn") == "0" {
+			return http.StatusOK, readFixture(t, fixture)
+		}
+		return http.StatusOK, empty
+	})
+}
+
+// localDate 页面上的日期按本地时区解析
+func localDate(year int, month time.Month, day int) string {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local).UTC().Format(time.RFC3339)
+}
+
+func TestBaiduNews(t *testing.T) {
+	e := &BaiduEngine{client: baiduVerticalClient(t, "/s", "baidu_news.html", "<html></html>")}
+	results, err := e.Search(context.Background(), "golang", 10, SearchOptions{Type: SearchTypeNews})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 百度自身的搜索页和相对链接被跳过
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+
+	want := SearchResult{
+		Title:       "Go 1.22 正式发布",
+		URL:         "https://www.infoq.cn/article/go-122",
+		Description: "新版本支持 range over int",
+		Source:      "InfoQ",
+		Engine:      "baidu",
+		Thumbnail:   "https://t7.baidu.com/it/u=1.jpg",
+		Publisher:   "InfoQ",
+		PublishedAt: localDate(2024, 2, 7),
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+
+	// 没有来源时使用链接的域名
+	if r := results[1]; r.Title != "Go 泛型实践" || r.Description != "泛型的常见用法" || r.Source != "news.example.com" {
+		t.Errorf("results[1] = %+v", r)
+	}
+}
+
+func TestBaiduImages(t *testing.T) {
+	e := &BaiduEngine{client: baiduVerticalClient(t, "/search/acjson", "baidu_images.json", `{"data":[]}`)}
+	results, err := e.Search(context.Background(), "gopher", 10, SearchOptions{Type: SearchTypeImages})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+
+	want := SearchResult{
+		Title:     "Go 语言吉祥物 Gopher",
+		URL:       "https://go.dev/blog/gopher",
+		Source:    "go.dev",
+		Engine:    "baidu",
+		Thumbnail: "https://img0.baidu.com/it/u=1.jpg",
+		MediaURL:  "https://go.dev/images/gopher.png",
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+
+	// 接口中的非法转义 \' 被修正；没有来源页面时使用图片地址
+	if r := results[1]; r.Title != "It's media only" || r.URL != "https://example.com/g.png" {
+		t.Errorf("results[1] = %+v", r)
+	}
+}
+
+func TestBaiduVideos(t *testing.T) {
+	e := &BaiduEngine{client: baiduVerticalClient(t, "/sf/vsearch", "baidu_videos.html", "<html></html>")}
+	results, err := e.Search(context.Background(), "go 并发", 10, SearchOptions{Type: SearchTypeVideos})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+
+	want := SearchResult{
+		Title:       "Go 并发编程入门",
+		URL:         "https://haokan.baidu.com/v?vid=123",
+		Source:      "haokan.baidu.com",
+		Engine:      "baidu",
+		Thumbnail:   "https://vdposter.bdstatic.com/1.jpg",
+		MediaURL:    "https://haokan.baidu.com/v?vid=123",
+		Duration:    "12:34",
+		Publisher:   "好看视频",
+		PublishedAt: localDate(2024, 1, 15),
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+
+	// 没有标题元素时使用链接的 title 属性
+	if r := results[1]; r.Title != "Go 语言教程" || r.Duration != "1:02:03" {
+		t.Errorf("results[1] = %+v", r)
+	}
+}
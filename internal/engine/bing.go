@@ -22,7 +22,7 @@ type BingEngine struct {
 }
 
 // NewBingEngine 创建 Bing 搜索引擎实例
-func NewBingEngine(proxyURL string) *BingEngine {
+func NewBingEngine(proxyURL string, limiter *RateLimiter) *BingEngine {
 	jar, _ := cookiejar.New(nil)
 	
 	transport := &http.Transport{}
@@ -33,9 +33,8 @@ func NewBingEngine(proxyURL string) *BingEngine {
 	}
 
 	client := &http.Client{
-		Timeout:   30 * time.Second,
 		Jar:       jar,
-		Transport: transport,
+		Transport: newRateLimitedTransport(limiter, transport),
 	}
 
 	return &BingEngine{
@@ -49,13 +48,49 @@ func (e *BingEngine) Name() string {
 	return "bing"
 }
 
+// Capabilities 返回引擎能力描述
+func (e *BingEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryWeb,
+		Description: "Bing international edition",
+		Verticals:   SearchTypes,
+		Filters:     []string{FilterFreshness, FilterDateRange, FilterRegion, FilterLanguage},
+		MaxResults:  60,
+		Pagination:  true,
+		RegionFocus: RegionGlobal,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符
+func (e *BingEngine) QueryDialect() QueryDialect {
+	return standardDialect
+}
+
 // Search 执行 Bing 搜索
-func (e *BingEngine) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+func (e *BingEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	// 根据搜索类型选择分页抓取函数
+	fetchPage := e.searchPage
+	switch opts.Type {
+	case SearchTypeNews:
+		fetchPage = e.searchNewsPage
+	case SearchTypeImages:
+		fetchPage = e.searchImagesPage
+	case SearchTypeVideos:
+		fetchPage = e.searchVideosPage
+	}
+
+	// 图片、视频每页 35 条，其余每页 10 条
+	pageSize := 10
+	if opts.Type == SearchTypeImages || opts.Type == SearchTypeVideos {
+		pageSize = 35
+	}
+	pn, skip := pageWindow(opts.Offset, pageSize)
+	startPn := pn
+
 	var allResults []SearchResult
-	pn := 0
 
-	for len(allResults) < limit {
-		results, err := e.searchPage(ctx, query, pn)
+	for len(allResults) < skip+limit {
+		results, err := fetchPage(ctx, query, pn, opts)
 		if err != nil {
 			if len(allResults) > 0 {
 				// 如果已经有一些结果，就返回这些
@@ -71,30 +106,29 @@ func (e *BingEngine) Search(ctx context.Context, query string, limit int) ([]Sea
 		allResults = append(allResults, results...)
 		pn++
 
-		if pn > 5 {
+		if pn > startPn+5 {
 			break
 		}
 	}
 
-	if len(allResults) > limit {
-		allResults = allResults[:limit]
-	}
-
-	return allResults, nil
+	return window(allResults, skip, limit), nil
 }
 
 // searchPage 搜索单页结果
-func (e *BingEngine) searchPage(ctx context.Context, query string, page int) ([]SearchResult, error) {
+func (e *BingEngine) searchPage(ctx context.Context, query string, page int, opts SearchOptions) ([]SearchResult, error) {
 	// 构建请求 URL - 使用国际版
-	searchURL := fmt.Sprintf("https://www.bing.com/search?q=%s&first=%d&setlang=en",
-		url.QueryEscape(query), 1+page*10)
+	params := e.buildParams(query, 1+page*10, opts)
+	if filters := bingFreshnessFilter(opts, time.Now()); filters != "" {
+		params.Set("filters", filters)
+	}
+	searchURL := fmt.Sprintf("https://www.bing.com/search?%s", params.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request failed: %w", err)
 	}
 
-	e.setHeaders(req)
+	e.setHeaders(req, opts)
 
 	resp, err := e.client.Do(req)
 	if err != nil {
@@ -133,11 +167,100 @@ func (e *BingEngine) searchPage(ctx context.Context, query string, page int) ([]
 	return results, nil
 }
 
+// InstantAnswer 从搜索结果页提取精选摘要或右侧实体卡片
+func (e *BingEngine) InstantAnswer(ctx context.Context, query string) (*InstantAnswer, error) {
+	searchURL := fmt.Sprintf("https://www.bing.com/search?%s", e.buildParams(query, 1, SearchOptions{}).Encode())
+
+	doc, err := e.fetchDocument(ctx, searchURL, SearchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("bing instant answer failed: %w", err)
+	}
+
+	answer := &InstantAnswer{Query: query, Engine: "bing"}
+
+	// 精选摘要位于结果列表顶部的 li.b_ans 中
+	doc.Find("#b_results > li.b_ans").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		answer.Answer = firstText(s, ".b_focusTextLarge", ".b_focusTextMedium", ".b_focusTextSmall")
+		answer.Abstract = firstText(s, ".rwrl", ".b_paractl", ".b_snippet")
+		if answer.Answer == "" && answer.Abstract == "" {
+			return true
+		}
+
+		answer.Heading = firstText(s, "h2")
+		if link := s.Find("h2 a[href^='http'], a.b_algoheader[href^='http']").First(); link.Length() > 0 {
+			answer.SourceURL, _ = link.Attr("href")
+			answer.Source = hostOf(answer.SourceURL)
+		}
+		return false
+	})
+
+	// 右侧实体卡片：标题、简介和属性
+	if pane := doc.Find("#b_context .b_entityTP").First(); pane.Length() > 0 {
+		if answer.Heading == "" {
+			answer.Heading = firstText(pane, ".b_entityTitle")
+		}
+		if answer.Abstract == "" {
+			answer.Abstract = firstText(pane, ".b_snippet", ".b_lBottom")
+			if link := pane.Find(".b_snippet a[href^='http']").Last(); link.Length() > 0 {
+				answer.SourceURL, _ = link.Attr("href")
+				answer.Source = hostOf(answer.SourceURL)
+			}
+		}
+		pane.Find(".b_vList .b_factrow, .b_factrow").Each(func(i int, row *goquery.Selection) {
+			// 行文本形如 "标签: 值"，标签在 .b_demoteText 中
+			label := cleanText(row.Find(".b_demoteText").First().Text())
+			value := cleanText(strings.TrimPrefix(cleanText(row.Text()), label))
+			label = strings.TrimSpace(strings.TrimSuffix(label, ":"))
+			if label != "" && value != "" {
+				answer.Infobox = append(answer.Infobox, InfoboxEntry{Label: label, Value: value})
+			}
+		})
+	}
+
+	return answer, nil
+}
+
+// buildParams 构建通用查询参数（关键词、分页、地区、语言）
+func (e *BingEngine) buildParams(query string, first int, opts SearchOptions) url.Values {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("first", fmt.Sprintf("%d", first))
+
+	setlang := "en"
+	if opts.Language != "" {
+		setlang = opts.Language
+	}
+	params.Set("setlang", setlang)
+
+	if opts.Region != "" {
+		params.Set("cc", opts.Region)
+	}
+
+	return params
+}
+
+// bingFreshnessFilter 生成网页/视频搜索的时间过滤参数 filters=ex1:"ezN"
+func bingFreshnessFilter(opts SearchOptions, now time.Time) string {
+	switch opts.Freshness {
+	case FreshnessDay:
+		return `ex1:"ez1"`
+	case FreshnessWeek:
+		return `ex1:"ez2"`
+	case FreshnessMonth:
+		return `ex1:"ez3"`
+	case FreshnessYear, FreshnessCustom:
+		// ez5 使用自 1970-01-01 起的天数表示区间
+		from, to, _ := opts.timeRange(now)
+		return fmt.Sprintf(`ex1:"ez5_%d_%d"`, from.Unix()/86400, to.Unix()/86400)
+	}
+	return ""
+}
+
 // setHeaders 设置请求头
-func (e *BingEngine) setHeaders(req *http.Request) {
+func (e *BingEngine) setHeaders(req *http.Request, opts SearchOptions) {
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Accept-Language", acceptLanguage(opts.Language, "en-US,en;q=0.9"))
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Pragma", "no-cache")
 	req.Header.Set("Sec-Fetch-Dest", "document")
@@ -0,0 +1,259 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// fetchDocument 请求 Bing 页面并解析为文档
+func (e *BingEngine) fetchDocument(ctx context.Context, searchURL string, opts SearchOptions) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+
+	e.setHeaders(req, opts)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML failed: %w", err)
+	}
+
+	return doc, nil
+}
+
+// searchNewsPage 搜索单页新闻结果
+func (e *BingEngine) searchNewsPage(ctx context.Context, query string, page int, opts SearchOptions) ([]SearchResult, error) {
+	now := time.Now()
+
+	// 新闻使用 qft=interval="N"：7 一天内，8 一周内，9 一个月内
+	params := e.buildParams(query, 1+page*10, opts)
+	switch opts.Freshness {
+	case FreshnessDay:
+		params.Set("qft", `interval="7"`)
+	case FreshnessWeek:
+		params.Set("qft", `interval="8"`)
+	case FreshnessMonth:
+		params.Set("qft", `interval="9"`)
+	}
+	searchURL := fmt.Sprintf("https://www.bing.com/news/search?%s", params.Encode())
+
+	doc, err := e.fetchDocument(ctx, searchURL, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+
+	doc.Find("div.news-card").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("url")
+		title, _ := s.Attr("data-title")
+		if title == "" {
+			title = strings.TrimSpace(s.Find("a.title").First().Text())
+		}
+		if href == "" || title == "" || !strings.HasPrefix(href, "http") {
+			return
+		}
+
+		publisher, _ := s.Attr("data-author")
+
+		description := strings.TrimSpace(s.Find(".snippet").First().Text())
+
+		thumbnail := ""
+		if img := s.Find(".image img").First(); img.Length() > 0 {
+			if src, ok := img.Attr("data-src-hq"); ok {
+				thumbnail = src
+			} else if src, ok := img.Attr("src"); ok && !strings.HasPrefix(src, "data:") {
+				thumbnail = src
+			}
+			if strings.HasPrefix(thumbnail, "/") {
+				thumbnail = "https://www.bing.com" + thumbnail
+			}
+		}
+
+		// 发布时间为相对时间，如 "2h"、"3d"
+		publishedAt := ""
+		if timeEl := s.Find(".source span[aria-label]").First(); timeEl.Length() > 0 {
+			publishedAt = parseDisplayTime(timeEl.Text(), now)
+		}
+
+		source := ""
+		if parsedURL, err := url.Parse(href); err == nil {
+			source = parsedURL.Host
+		}
+
+		results = append(results, SearchResult{
+			Title:       title,
+			URL:         href,
+			Description: description,
+			Source:      source,
+			Engine:      "bing",
+			Thumbnail:   thumbnail,
+			Publisher:   publisher,
+			PublishedAt: publishedAt,
+		})
+	})
+
+	// 一年内和自定义区间没有对应参数，按发布时间过滤
+	if opts.Freshness == FreshnessYear || opts.Freshness == FreshnessCustom {
+		results = filterByPublishedTime(results, opts, now)
+	}
+
+	log.Printf("🔍 Bing news page %d: found %d results", page, len(results))
+	return results, nil
+}
+
+// bingImageMeta 图片结果 a.iusc 上 m 属性中的 JSON
+type bingImageMeta struct {
+	MediaURL     string `json:"murl"`
+	ThumbnailURL string `json:"turl"`
+	PageURL      string `json:"purl"`
+	Title        string `json:"t"`
+	Description  string `json:"desc"`
+}
+
+// searchImagesPage 搜索单页图片结果
+func (e *BingEngine) searchImagesPage(ctx context.Context, query string, page int, opts SearchOptions) ([]SearchResult, error) {
+	params := e.buildParams(query, 1+page*35, opts)
+	params.Set("count", "35")
+	params.Set("mmasync", "1")
+	// 图片使用 qft=+filterui:age-ltN，N 为分钟数
+	if from, _, ok := opts.timeRange(time.Now()); ok {
+		params.Set("qft", fmt.Sprintf("+filterui:age-lt%d", int(time.Since(from).Minutes())))
+	}
+	searchURL := fmt.Sprintf("https://www.bing.com/images/async?%s", params.Encode())
+
+	doc, err := e.fetchDocument(ctx, searchURL, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+
+	doc.Find("a.iusc").Each(func(i int, s *goquery.Selection) {
+		raw, ok := s.Attr("m")
+		if !ok {
+			return
+		}
+
+		var meta bingImageMeta
+		if err := json.Unmarshal([]byte(raw), &meta); err != nil {
+			return
+		}
+		if meta.PageURL == "" || meta.MediaURL == "" {
+			return
+		}
+
+		source := ""
+		if parsedURL, err := url.Parse(meta.PageURL); err == nil {
+			source = parsedURL.Host
+		}
+
+		results = append(results, SearchResult{
+			Title:       cleanText(meta.Title),
+			URL:         meta.PageURL,
+			Description: cleanText(meta.Description),
+			Source:      source,
+			Engine:      "bing",
+			Thumbnail:   meta.ThumbnailURL,
+			MediaURL:    meta.MediaURL,
+		})
+	})
+
+	log.Printf("🔍 Bing images page %d: found %d results", page, len(results))
+	return results, nil
+}
+
+// bingVideoMeta 视频结果 div.vrhdata 上 vrhm 属性中的 JSON
+type bingVideoMeta struct {
+	Title    string `json:"title"`
+	PageURL  string `json:"pgurl"`
+	MediaURL string `json:"murl"`
+	Duration string `json:"du"`
+}
+
+// searchVideosPage 搜索单页视频结果
+func (e *BingEngine) searchVideosPage(ctx context.Context, query string, page int, opts SearchOptions) ([]SearchResult, error) {
+	params := e.buildParams(query, 1+page*35, opts)
+	if filters := bingFreshnessFilter(opts, time.Now()); filters != "" {
+		params.Set("filters", filters)
+	}
+	searchURL := fmt.Sprintf("https://www.bing.com/videos/search?%s", params.Encode())
+
+	doc, err := e.fetchDocument(ctx, searchURL, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+
+	doc.Find("div.dg_u").Each(func(i int, s *goquery.Selection) {
+		raw, ok := s.Find("div.vrhdata").First().Attr("vrhm")
+		if !ok {
+			return
+		}
+
+		var meta bingVideoMeta
+		if err := json.Unmarshal([]byte(raw), &meta); err != nil {
+			return
+		}
+
+		href := meta.PageURL
+		if href == "" {
+			href = meta.MediaURL
+		}
+		if href == "" || meta.Title == "" {
+			return
+		}
+
+		thumbnail := ""
+		if img := s.Find("img").First(); img.Length() > 0 {
+			if src, ok := img.Attr("data-src-hq"); ok {
+				thumbnail = src
+			} else if src, ok := img.Attr("src"); ok && !strings.HasPrefix(src, "data:") {
+				thumbnail = src
+			}
+		}
+
+		publisher := strings.TrimSpace(s.Find(".mc_vtvc_meta_row_channel").First().Text())
+
+		source := ""
+		if parsedURL, err := url.Parse(href); err == nil {
+			source = parsedURL.Host
+		}
+
+		results = append(results, SearchResult{
+			Title:     cleanText(meta.Title),
+			URL:       href,
+			Source:    source,
+			Engine:    "bing",
+			Thumbnail: thumbnail,
+			MediaURL:  meta.MediaURL,
+			Duration:  meta.Duration,
+			Publisher: publisher,
+		})
+	})
+
+	log.Printf("🔍 Bing videos page %d: found %d results", page, len(results))
+	return results, nil
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// bingVerticalClient 第一页返回样本页面，之后的页面为空
+func bingVerticalClient(t *testing.T, path, fixture string) *http.Client {
+	return fixtureClient(func(req *http.Request) (int, string) {
+		if req.URL.Path == path && req.URL.Query().Get("first") == "1" {
+			return http.StatusOK, readFixture(t, fixture)
+		}
+		return http.StatusOK, "<html></html>"
+	})
+}
+
+func TestBingNews(t *testing.T) {
+	e := &BingEngine{client: bingVerticalClient(t, "/news/search", "bing_news.html")}
+	results, err := e.Search(context.Background(), "golang", 10, SearchOptions{Type: SearchTypeNews})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 相对链接被跳过
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+
+	r := results[0]
+	if r.Title != "Go 1.22 released" || r.URL != "https://www.theverge.com/go-release" || r.Source != "www.theverge.com" {
+		t.Errorf("results[0] = %+v", r)
+	}
+	if r.Description != "The new release brings range over integers." || r.Publisher != "The Verge" {
+		t.Errorf("results[0] text = %+v", r)
+	}
+	if r.Thumbnail != "https://www.bing.com/th?id=OVFT.abc&pid=News" || r.PublishedAt == "" {
+		t.Errorf("results[0] thumbnail = %q, published = %q", r.Thumbnail, r.PublishedAt)
+	}
+
+	// 没有 data-title 时使用标题链接的文字
+	if results[1].Title != "Title from link" {
+		t.Errorf("results[1].Title = %q", results[1].Title)
+	}
+}
+
+func TestBingImages(t *testing.T) {
+	e := &BingEngine{client: bingVerticalClient(t, "/images/async", "bing_images.html")}
+	results, err := e.Search(context.Background(), "gopher", 10, SearchOptions{Type: SearchTypeImages})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1: %+v", len(results), results)
+	}
+
+	want := SearchResult{
+		Title:       "The Go Gopher",
+		URL:         "https://go.dev/blog/gopher",
+		Description: "Gopher artwork",
+		Source:      "go.dev",
+		Engine:      "bing",
+		Thumbnail:   "https://tse1.mm.bing.net/th?id=OIP.1",
+		MediaURL:    "https://go.dev/images/gophers/biplane.jpg",
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+}
+
+func TestBingVideos(t *testing.T) {
+	e := &BingEngine{client: bingVerticalClient(t, "/videos/search", "bing_videos.html")}
+	results, err := e.Search(context.Background(), "go concurrency", 10, SearchOptions{Type: SearchTypeVideos})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+
+	r := results[0]
+	if r.Title != "Go Concurrency Patterns" || r.URL != "https://www.youtube.com/watch?v=f6kdp27TYZs" || r.Duration != "51:26" {
+		t.Errorf("results[0] = %+v", r)
+	}
+	if r.Thumbnail != "https://tse2.mm.bing.net/th?id=OVP.1" || r.Publisher != "Google for Developers" || r.Source != "www.youtube.com" {
+		t.Errorf("results[0] metadata = %+v", r)
+	}
+
+	// 没有页面地址时使用媒体地址
+	if r := results[1]; r.URL != "https://videos.example.com/v.mp4" || r.Thumbnail != "https://tse3.mm.bing.net/th?id=OVP.2" {
+		t.Errorf("results[1] = %+v", r)
+	}
+}
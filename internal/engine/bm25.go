@@ -0,0 +1,190 @@
+package engine
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ProcessorBM25 BM25 重排处理器名称
+const ProcessorBM25 = "bm25"
+
+// BM25 参数
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// BM25Reranker 用 BM25 对标题和描述相对查询打分，与引擎排名（融合得分）按权重混合后重新排序
+type BM25Reranker struct {
+	// BM25Weight BM25 得分的权重
+	BM25Weight float64
+	// RankWeight 引擎排名（rrf_score）的权重
+	RankWeight float64
+}
+
+// NewBM25Reranker 创建 BM25 重排处理器
+func NewBM25Reranker(bm25Weight, rankWeight float64) *BM25Reranker {
+	return &BM25Reranker{BM25Weight: bm25Weight, RankWeight: rankWeight}
+}
+
+// Name 返回处理器名称
+func (r *BM25Reranker) Name() string {
+	return ProcessorBM25
+}
+
+// Process 计算每条结果的 BM25 得分，两项得分都按最大值归一化后加权求和写入 Score，并按 Score 排序
+func (r *BM25Reranker) Process(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error) {
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	queryTokens := tokenize(queryText(pc))
+	if len(queryTokens) == 0 {
+		return results, nil
+	}
+
+	// 标题比描述更能代表页面主题，计入两次
+	docs := make([][]string, len(results))
+	for i, res := range results {
+		docs[i] = tokenize(res.Title + " " + res.Title + " " + res.Description)
+	}
+	bm25 := bm25Scores(queryTokens, docs)
+
+	maxBM25, maxRank := 0.0, 0.0
+	for i := range results {
+		maxBM25 = math.Max(maxBM25, bm25[i])
+		maxRank = math.Max(maxRank, results[i].RRFScore)
+	}
+
+	for i := range results {
+		score := 0.0
+		if maxBM25 > 0 {
+			score += r.BM25Weight * bm25[i] / maxBM25
+		}
+		if maxRank > 0 {
+			score += r.RankWeight * results[i].RRFScore / maxRank
+		}
+		results[i].Score = math.Round(score*10000) / 10000
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results, nil
+}
+
+// queryText 返回用于打分的查询词（去掉 site:、-排除词等操作符）
+func queryText(pc *ProcessContext) string {
+	if pc.Query == nil {
+		return pc.Request.Query
+	}
+	var parts []string
+	for _, group := range pc.Query.Groups {
+		for _, term := range group {
+			parts = append(parts, term.Text)
+		}
+	}
+	for _, term := range pc.Query.InTitle {
+		parts = append(parts, term.Text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// bm25Scores 计算每个文档相对查询词的 BM25 得分
+func bm25Scores(query []string, docs [][]string) []float64 {
+	n := float64(len(docs))
+	totalLen := 0
+	df := make(map[string]int)
+	tfs := make([]map[string]int, len(docs))
+
+	for i, doc := range docs {
+		totalLen += len(doc)
+		tf := make(map[string]int)
+		for _, token := range doc {
+			tf[token]++
+		}
+		for token := range tf {
+			df[token]++
+		}
+		tfs[i] = tf
+	}
+	avgLen := float64(totalLen) / n
+	if avgLen == 0 {
+		avgLen = 1
+	}
+
+	// 查询中重复的词只计一次
+	seen := make(map[string]bool)
+	var terms []string
+	for _, token := range query {
+		if !seen[token] {
+			seen[token] = true
+			terms = append(terms, token)
+		}
+	}
+
+	scores := make([]float64, len(docs))
+	for i, doc := range docs {
+		docLen := float64(len(doc))
+		for _, term := range terms {
+			tf := float64(tfs[i][term])
+			if tf == 0 {
+				continue
+			}
+			idf := math.Log(1 + (n-float64(df[term])+0.5)/(float64(df[term])+0.5))
+			scores[i] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*docLen/avgLen))
+		}
+	}
+	return scores
+}
+
+// tokenize 分词：拉丁文字按字母数字切分并转小写；中日韩文字没有空格，按相邻两字（bigram）切分，单字保留为一个词
+func tokenize(text string) []string {
+	var tokens []string
+	var word []rune
+	var cjk []rune
+
+	flushWord := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	flushCJK := func() {
+		switch {
+		case len(cjk) == 1:
+			tokens = append(tokens, string(cjk))
+		case len(cjk) > 1:
+			for i := 0; i+1 < len(cjk); i++ {
+				tokens = append(tokens, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
+
+// isCJK 判断是否为中日韩文字
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Go Generics, v1.18!", []string{"go", "generics", "v1", "18"}},
+		{"并发编程", []string{"并发", "发编", "编程"}},
+		{"Go语言 并发", []string{"go", "语言", "并发"}},
+		{"学 Go", []string{"学", "go"}},
+		{"カタカナ 한국어", []string{"カタ", "タカ", "カナ", "한국", "국어"}},
+		{"  --  ", nil},
+	}
+	for _, tt := range tests {
+		if got := tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestBM25Scores(t *testing.T) {
+	docs := [][]string{
+		{"go", "generics", "tutorial"},
+		{"go", "go", "go", "generics"},
+		{"python", "tutorial"},
+	}
+	scores := bm25Scores([]string{"generics", "generics"}, docs)
+
+	if scores[2] != 0 {
+		t.Errorf("document without query terms scored %v", scores[2])
+	}
+	if scores[0] <= 0 || scores[1] <= 0 {
+		t.Fatalf("matching documents scored %v", scores)
+	}
+	// 词频相同时较短的文档得分更高；查询中重复的词只计一次
+	if scores[0] <= scores[1] {
+		t.Errorf("shorter document scored %v, longer %v", scores[0], scores[1])
+	}
+	if single := bm25Scores([]string{"generics"}, docs); single[0] != scores[0] {
+		t.Errorf("repeated query term changed score: %v vs %v", single[0], scores[0])
+	}
+}
+
+func TestBM25RerankerProcess(t *testing.T) {
+	results := []SearchResult{
+		{URL: "https://a.example.com/", Title: "Python 教程", RRFScore: 1.0 / 61},
+		{URL: "https://b.example.com/", Title: "Go 语言并发编程", Description: "goroutine 与 channel", RRFScore: 1.0 / 62},
+		{URL: "https://c.example.com/", Title: "Go 入门", RRFScore: 1.0 / 63},
+	}
+	pc := &ProcessContext{Query: ParseQuery("并发编程 site:example.com")}
+
+	got, err := NewBM25Reranker(1, 0).Process(context.Background(), pc, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].URL != "https://b.example.com/" || got[0].Score != 1 {
+		t.Errorf("top result = %+v, want b with score 1", got[0])
+	}
+	// 没有匹配词的结果保持原有顺序
+	if got[1].URL != "https://a.example.com/" || got[1].Score != 0 {
+		t.Errorf("second result = %+v, want a with score 0", got[1])
+	}
+}
+
+func TestBM25RerankerRankWeight(t *testing.T) {
+	results := []SearchResult{
+		{URL: "https://a.example.com/", Title: "unrelated", RRFScore: 0.02},
+		{URL: "https://b.example.com/", Title: "golang generics", RRFScore: 0.01},
+	}
+	pc := &ProcessContext{Request: SearchRequest{Query: "golang generics"}}
+
+	// 只看引擎排名时顺序不变，得分按最大值归一化
+	got, _ := NewBM25Reranker(0, 1).Process(context.Background(), pc, append([]SearchResult(nil), results...))
+	if got[0].URL != "https://a.example.com/" || got[0].Score != 1 || got[1].Score != 0.5 {
+		t.Errorf("rank-only order = %+v", got)
+	}
+
+	got, _ = NewBM25Reranker(0.7, 0.3).Process(context.Background(), pc, append([]SearchResult(nil), results...))
+	if got[0].URL != "https://b.example.com/" || got[0].Score != 0.85 || got[1].Score != 0.3 {
+		t.Errorf("mixed order = %+v", got)
+	}
+}
+
+func TestBM25RerankerEmptyQuery(t *testing.T) {
+	results := []SearchResult{{Title: "b", RRFScore: 0.01}, {Title: "a", RRFScore: 0.02}}
+	pc := &ProcessContext{Request: SearchRequest{Query: "  ?! "}}
+
+	got, _ := NewBM25Reranker(1, 1).Process(context.Background(), pc, results)
+	if got[0].Title != "b" || got[0].Score != 0 {
+		t.Errorf("results changed without query tokens: %+v", got)
+	}
+}
@@ -145,8 +145,8 @@ func (bm *BrowserManager) Initialize(proxyURL string, headless bool) error {
 	return nil
 }
 
-// NewTabContext 创建新的标签页上下文
-func (bm *BrowserManager) NewTabContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+// NewTabContext 创建新的标签页上下文，超过 timeout 或 ctx 结束（调用方取消、超过截止时间）时关闭标签页
+func (bm *BrowserManager) NewTabContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
 	bm.mu.Lock()
 	defer bm.mu.Unlock()
 
@@ -164,8 +164,12 @@ func (bm *BrowserManager) NewTabContext(timeout time.Duration) (context.Context,
 	// 添加超时
 	timeoutCtx, timeoutCancel := context.WithTimeout(tabCtx, timeout)
 
+	// 标签页从浏览器上下文派生，需要单独跟随调用方的上下文结束
+	stop := context.AfterFunc(ctx, timeoutCancel)
+
 	// 返回组合的 cancel 函数
 	return timeoutCtx, func() {
+		stop()
 		timeoutCancel()
 		tabCancel()
 	}
@@ -17,14 +17,16 @@ type BrowserBaiduEngine struct {
 	proxyURL string
 	headless bool
 	timeout  time.Duration
+	limiter  *RateLimiter
 }
 
 // NewBrowserBaiduEngine 创建浏览器版 Baidu 搜索引擎
-func NewBrowserBaiduEngine(proxyURL string, headless bool) *BrowserBaiduEngine {
+func NewBrowserBaiduEngine(proxyURL string, headless bool, limiter *RateLimiter) *BrowserBaiduEngine {
 	return &BrowserBaiduEngine{
 		proxyURL: proxyURL,
 		headless: headless,
 		timeout:  60 * time.Second,
+		limiter:  limiter,
 	}
 }
 
@@ -33,19 +35,40 @@ func (e *BrowserBaiduEngine) Name() string {
 	return "browser_baidu"
 }
 
+// Capabilities 返回引擎能力描述
+func (e *BrowserBaiduEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:        CategoryWeb,
+		Description:     "Baidu through headless Chrome",
+		Verticals:       webOnly,
+		Filters:         []string{FilterFreshness, FilterDateRange},
+		MaxResults:      30,
+		Pagination:      true,
+		RequiresBrowser: true,
+		RegionFocus:     RegionCN,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符（百度不支持 -site:，OR 使用 |）
+func (e *BrowserBaiduEngine) QueryDialect() QueryDialect {
+	return QueryDialect{Site: true, FileType: true, InTitle: true, Phrase: true, Exclude: true, OR: "|"}
+}
+
 // Search 使用浏览器执行 Baidu 搜索
-func (e *BrowserBaiduEngine) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+func (e *BrowserBaiduEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
 	// 确保浏览器已初始化
 	bm := GetBrowserManager()
 	if err := bm.Initialize(e.proxyURL, e.headless); err != nil {
 		return nil, fmt.Errorf("failed to initialize browser: %w", err)
 	}
 
+	page, skip := pageWindow(opts.Offset, 10)
+	startPage := page
+
 	var allResults []SearchResult
-	page := 0
 
-	for len(allResults) < limit && page < 3 {
-		results, err := e.searchPage(ctx, query, page)
+	for len(allResults) < skip+limit && page < startPage+3 {
+		results, err := e.searchPage(ctx, query, page, opts)
 		if err != nil {
 			if len(allResults) > 0 {
 				break
@@ -61,24 +84,30 @@ func (e *BrowserBaiduEngine) Search(ctx context.Context, query string, limit int
 		page++
 	}
 
-	if len(allResults) > limit {
-		allResults = allResults[:limit]
-	}
-
-	return allResults, nil
+	return window(allResults, skip, limit), nil
 }
 
 // searchPage 搜索单页
-func (e *BrowserBaiduEngine) searchPage(ctx context.Context, query string, page int) ([]SearchResult, error) {
+func (e *BrowserBaiduEngine) searchPage(ctx context.Context, query string, page int, opts SearchOptions) ([]SearchResult, error) {
+	// 等待限流令牌，多个并发调用共享同一速率
+	if err := e.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
 	bm := GetBrowserManager()
 
 	// 创建新的 tab 上下文
-	tabCtx, cancel := bm.NewTabContext(e.timeout)
+	tabCtx, cancel := bm.NewTabContext(ctx, e.timeout)
 	defer cancel()
 
 	// 构建搜索 URL
-	searchURL := fmt.Sprintf("https://www.baidu.com/s?wd=%s&pn=%d",
-		url.QueryEscape(query), page*10)
+	params := url.Values{}
+	params.Set("wd", query)
+	params.Set("pn", fmt.Sprintf("%d", page*10))
+	if gpc := baiduTimeFilter(opts, time.Now()); gpc != "" {
+		params.Set("gpc", gpc)
+	}
+	searchURL := fmt.Sprintf("https://www.baidu.com/s?%s", params.Encode())
 
 	var html string
 
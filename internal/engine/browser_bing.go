@@ -2,7 +2,6 @@ package engine
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"log"
 	"net/url"
@@ -18,14 +17,16 @@ type BrowserBingEngine struct {
 	proxyURL string
 	headless bool
 	timeout  time.Duration
+	limiter  *RateLimiter
 }
 
 // NewBrowserBingEngine 创建浏览器版 Bing 搜索引擎
-func NewBrowserBingEngine(proxyURL string, headless bool) *BrowserBingEngine {
+func NewBrowserBingEngine(proxyURL string, headless bool, limiter *RateLimiter) *BrowserBingEngine {
 	return &BrowserBingEngine{
 		proxyURL: proxyURL,
 		headless: headless,
 		timeout:  60 * time.Second,
+		limiter:  limiter,
 	}
 }
 
@@ -34,19 +35,40 @@ func (e *BrowserBingEngine) Name() string {
 	return "browser_bing"
 }
 
+// Capabilities 返回引擎能力描述
+func (e *BrowserBingEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:        CategoryWeb,
+		Description:     "Bing through headless Chrome",
+		Verticals:       webOnly,
+		Filters:         []string{FilterFreshness, FilterDateRange, FilterRegion, FilterLanguage},
+		MaxResults:      30,
+		Pagination:      true,
+		RequiresBrowser: true,
+		RegionFocus:     RegionGlobal,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符
+func (e *BrowserBingEngine) QueryDialect() QueryDialect {
+	return standardDialect
+}
+
 // Search 使用浏览器执行 Bing 搜索
-func (e *BrowserBingEngine) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+func (e *BrowserBingEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
 	// 确保浏览器已初始化
 	bm := GetBrowserManager()
 	if err := bm.Initialize(e.proxyURL, e.headless); err != nil {
 		return nil, fmt.Errorf("failed to initialize browser: %w", err)
 	}
 
+	page, skip := pageWindow(opts.Offset, 10)
+	startPage := page
+
 	var allResults []SearchResult
-	page := 0
 
-	for len(allResults) < limit && page < 3 {
-		results, err := e.searchPage(ctx, query, page)
+	for len(allResults) < skip+limit && page < startPage+3 {
+		results, err := e.searchPage(ctx, query, page, opts)
 		if err != nil {
 			if len(allResults) > 0 {
 				break
@@ -62,24 +84,37 @@ func (e *BrowserBingEngine) Search(ctx context.Context, query string, limit int)
 		page++
 	}
 
-	if len(allResults) > limit {
-		allResults = allResults[:limit]
-	}
-
-	return allResults, nil
+	return window(allResults, skip, limit), nil
 }
 
 // searchPage 搜索单页
-func (e *BrowserBingEngine) searchPage(ctx context.Context, query string, page int) ([]SearchResult, error) {
+func (e *BrowserBingEngine) searchPage(ctx context.Context, query string, page int, opts SearchOptions) ([]SearchResult, error) {
+	// 等待限流令牌，多个并发调用共享同一速率
+	if err := e.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
 	bm := GetBrowserManager()
 
 	// 创建新的 tab 上下文
-	tabCtx, cancel := bm.NewTabContext(e.timeout)
+	tabCtx, cancel := bm.NewTabContext(ctx, e.timeout)
 	defer cancel()
 
 	// 构建搜索 URL - 使用国际版 Bing
-	searchURL := fmt.Sprintf("https://www.bing.com/search?q=%s&first=%d&setlang=en",
-		url.QueryEscape(query), 1+page*10)
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("first", fmt.Sprintf("%d", 1+page*10))
+	params.Set("setlang", "en")
+	if opts.Language != "" {
+		params.Set("setlang", opts.Language)
+	}
+	if opts.Region != "" {
+		params.Set("cc", opts.Region)
+	}
+	if filters := bingFreshnessFilter(opts, time.Now()); filters != "" {
+		params.Set("filters", filters)
+	}
+	searchURL := fmt.Sprintf("https://www.bing.com/search?%s", params.Encode())
 
 	var html string
 
@@ -182,30 +217,8 @@ func (e *BrowserBingEngine) parseHTML(html string) ([]SearchResult, error) {
 
 // extractRealURL 从 Bing 跳转链接中提取真实 URL
 func (e *BrowserBingEngine) extractRealURL(href string) string {
-	// 如果已经是正常 URL，直接返回
-	if !strings.Contains(href, "bing.com/ck/a") {
-		return href
-	}
-
-	// 解析 Bing 跳转 URL
-	parsed, err := url.Parse(href)
-	if err != nil {
-		return href
+	if target, ok := decodeBingClickURL(href); ok {
+		return target
 	}
-
-	// 尝试从 u 参数获取真实 URL（Base64 编码）
-	u := parsed.Query().Get("u")
-	if u != "" {
-		// Bing 使用 a1 前缀 + Base64 编码
-		if strings.HasPrefix(u, "a1") {
-			u = u[2:] // 移除 a1 前缀
-		}
-		// Base64 URL 解码
-		decoded, err := base64.RawURLEncoding.DecodeString(u)
-		if err == nil && len(decoded) > 0 {
-			return string(decoded)
-		}
-	}
-
 	return href
 }
@@ -17,14 +17,16 @@ type BrowserGoogleEngine struct {
 	proxyURL string
 	headless bool
 	timeout  time.Duration
+	limiter  *RateLimiter
 }
 
 // NewBrowserGoogleEngine 创建浏览器版 Google 搜索引擎
-func NewBrowserGoogleEngine(proxyURL string, headless bool) *BrowserGoogleEngine {
+func NewBrowserGoogleEngine(proxyURL string, headless bool, limiter *RateLimiter) *BrowserGoogleEngine {
 	return &BrowserGoogleEngine{
 		proxyURL: proxyURL,
 		headless: headless,
 		timeout:  60 * time.Second,
+		limiter:  limiter,
 	}
 }
 
@@ -33,19 +35,40 @@ func (e *BrowserGoogleEngine) Name() string {
 	return "browser_google"
 }
 
+// Capabilities 返回引擎能力描述
+func (e *BrowserGoogleEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:        CategoryWeb,
+		Description:     "Google through headless Chrome",
+		Verticals:       webOnly,
+		Filters:         []string{FilterFreshness, FilterDateRange, FilterRegion, FilterLanguage},
+		MaxResults:      30,
+		Pagination:      true,
+		RequiresBrowser: true,
+		RegionFocus:     RegionGlobal,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符
+func (e *BrowserGoogleEngine) QueryDialect() QueryDialect {
+	return standardDialect
+}
+
 // Search 使用浏览器执行 Google 搜索
-func (e *BrowserGoogleEngine) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+func (e *BrowserGoogleEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
 	// 确保浏览器已初始化
 	bm := GetBrowserManager()
 	if err := bm.Initialize(e.proxyURL, e.headless); err != nil {
 		return nil, fmt.Errorf("failed to initialize browser: %w", err)
 	}
 
+	page, skip := pageWindow(opts.Offset, 10)
+	startPage := page
+
 	var allResults []SearchResult
-	page := 0
 
-	for len(allResults) < limit && page < 3 {
-		results, err := e.searchPage(ctx, query, page)
+	for len(allResults) < skip+limit && page < startPage+3 {
+		results, err := e.searchPage(ctx, query, page, opts)
 		if err != nil {
 			if len(allResults) > 0 {
 				break
@@ -61,25 +84,56 @@ func (e *BrowserGoogleEngine) Search(ctx context.Context, query string, limit in
 		page++
 	}
 
-	if len(allResults) > limit {
-		allResults = allResults[:limit]
+	return window(allResults, skip, limit), nil
+}
+
+// searchPage 搜索单页
+func (e *BrowserGoogleEngine) searchPage(ctx context.Context, query string, page int, opts SearchOptions) ([]SearchResult, error) {
+	// 构建搜索 URL
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("start", fmt.Sprintf("%d", page*10))
+	params.Set("hl", "en")
+	if opts.Language != "" {
+		params.Set("hl", opts.Language)
+		params.Set("lr", "lang_"+opts.Language)
+	}
+	if opts.Region != "" {
+		params.Set("gl", opts.Region)
+	}
+	if tbs := googleTimeFilter(opts, time.Now()); tbs != "" {
+		params.Set("tbs", tbs)
 	}
+	searchURL := fmt.Sprintf("https://www.google.com/search?%s", params.Encode())
 
-	return allResults, nil
+	html, err := e.fetchHTML(ctx, searchURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析 HTML
+	results, err := e.parseHTML(html)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ [BrowserGoogle] Page %d: found %d results", page, len(results))
+	return results, nil
 }
 
-// searchPage 搜索单页
-func (e *BrowserGoogleEngine) searchPage(ctx context.Context, query string, page int) ([]SearchResult, error) {
+// fetchHTML 在新标签页中打开搜索结果页并返回页面 HTML
+func (e *BrowserGoogleEngine) fetchHTML(ctx context.Context, searchURL string) (string, error) {
+	// 等待限流令牌，多个并发调用共享同一速率
+	if err := e.limiter.Wait(ctx); err != nil {
+		return "", err
+	}
+
 	bm := GetBrowserManager()
 
 	// 创建新的 tab 上下文
-	tabCtx, cancel := bm.NewTabContext(e.timeout)
+	tabCtx, cancel := bm.NewTabContext(ctx, e.timeout)
 	defer cancel()
 
-	// 构建搜索 URL
-	searchURL := fmt.Sprintf("https://www.google.com/search?q=%s&start=%d&hl=en",
-		url.QueryEscape(query), page*10)
-
 	var html string
 
 	log.Printf("🌐 [BrowserGoogle] Navigating to: %s", searchURL)
@@ -104,19 +158,86 @@ func (e *BrowserGoogleEngine) searchPage(ctx context.Context, query string, page
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("browser navigation failed: %w", err)
+		return "", fmt.Errorf("browser navigation failed: %w", err)
 	}
 
 	log.Printf("🔍 [BrowserGoogle] Got page HTML, size: %d bytes", len(html))
+	return html, nil
+}
 
-	// 解析 HTML
-	results, err := e.parseHTML(html)
+// InstantAnswer 从搜索结果页提取精选摘要和知识面板
+func (e *BrowserGoogleEngine) InstantAnswer(ctx context.Context, query string) (*InstantAnswer, error) {
+	bm := GetBrowserManager()
+	if err := bm.Initialize(e.proxyURL, e.headless); err != nil {
+		return nil, fmt.Errorf("failed to initialize browser: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("hl", "en")
+	html, err := e.fetchHTML(ctx, fmt.Sprintf("https://www.google.com/search?%s", params.Encode()))
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("✅ [BrowserGoogle] Page %d: found %d results", page, len(results))
-	return results, nil
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML failed: %w", err)
+	}
+
+	answer := &InstantAnswer{Query: query, Engine: "browser_google"}
+
+	// 精选摘要：短答案在 .IZ6rdc，摘要段落在 .hgKElc
+	if snippet := doc.Find("block-component, div.xpdopen").First(); snippet.Length() > 0 {
+		answer.Answer = firstText(snippet, ".IZ6rdc", "[data-tts='answers']")
+		answer.Abstract = firstText(snippet, ".hgKElc", "[data-attrid='wa:/description']")
+		if link := snippet.Find(".yuRUbf a[href^='http'], a[href^='http']:has(h3)").First(); link.Length() > 0 {
+			answer.SourceURL, _ = link.Attr("href")
+			answer.Source = hostOf(answer.SourceURL)
+			answer.Heading = firstText(link, "h3")
+		}
+	}
+
+	// 知识面板：标题、简介和属性行
+	if panel := doc.Find("div.kp-wholepage, div.knowledge-panel").First(); panel.Length() > 0 {
+		if title := firstText(panel, "[data-attrid='title']"); title != "" {
+			answer.Heading = title
+		}
+		if answer.Abstract == "" {
+			answer.Abstract = firstText(panel, ".kno-rdesc span", "[data-attrid='description'] span")
+			if link := panel.Find(".kno-rdesc a[href^='http']").First(); link.Length() > 0 {
+				answer.SourceURL, _ = link.Attr("href")
+				answer.Source = cleanText(link.Text())
+			}
+		}
+		panel.Find("[data-attrid^='kc:/'], [data-attrid^='ss:/']").Each(func(i int, row *goquery.Selection) {
+			label := strings.TrimSuffix(firstText(row, ".w8qArf"), ":")
+			value := firstText(row, ".LrzXr", ".kno-fv")
+			if label != "" && value != "" {
+				answer.Infobox = append(answer.Infobox, InfoboxEntry{Label: strings.TrimSpace(label), Value: value})
+			}
+		})
+	}
+
+	return answer, nil
+}
+
+// googleTimeFilter 生成时间过滤参数 tbs：qdr:d/w/m/y 或 cdr:1,cd_min:M/D/YYYY,cd_max:M/D/YYYY
+func googleTimeFilter(opts SearchOptions, now time.Time) string {
+	switch opts.Freshness {
+	case FreshnessDay:
+		return "qdr:d"
+	case FreshnessWeek:
+		return "qdr:w"
+	case FreshnessMonth:
+		return "qdr:m"
+	case FreshnessYear:
+		return "qdr:y"
+	case FreshnessCustom:
+		from, to, _ := opts.timeRange(now)
+		return fmt.Sprintf("cdr:1,cd_min:%s,cd_max:%s", from.Format("1/2/2006"), to.Format("1/2/2006"))
+	}
+	return ""
 }
 
 // parseHTML 解析 HTML 提取搜索结果
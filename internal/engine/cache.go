@@ -0,0 +1,233 @@
+package engine
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultCache 搜索结果缓存
+type ResultCache interface {
+	Get(key string) (*SearchResponse, bool)
+	Set(key string, resp *SearchResponse)
+}
+
+// cacheKey 由规范化后的查询、引擎、数量、过滤条件和结果整理参数生成缓存键
+func cacheKey(req SearchRequest, engines []string, limit int) string {
+	data, _ := json.Marshal(struct {
+		Query         string         `json:"q"`
+		Engines       []string       `json:"e"`
+		Limit         int            `json:"l"`
+		Options       SearchOptions  `json:"o"`
+		MaxPerDomain  int            `json:"d,omitempty"`
+		GroupByDomain bool           `json:"g,omitempty"`
+		DomainRules   DomainRules    `json:"r"`
+		EngineOffsets map[string]int `json:"p,omitempty"`
+	}{strings.Join(strings.Fields(req.Query), " "), engines, limit, req.SearchOptions, req.MaxPerDomain, req.GroupByDomain, req.DomainRules, req.EngineOffsets})
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// copyResponse 复制响应，避免调用方修改缓存中的数据
+func copyResponse(resp *SearchResponse) *SearchResponse {
+	cp := *resp
+	cp.Results = append([]SearchResult(nil), resp.Results...)
+	cp.Warnings = append([]string(nil), resp.Warnings...)
+	cp.ServedBy = append([]string(nil), resp.ServedBy...)
+	cp.Outcomes = append([]EngineOutcome(nil), resp.Outcomes...)
+	cp.Groups = append([]DomainGroup(nil), resp.Groups...)
+	return &cp
+}
+
+// cacheEntry 缓存条目
+type cacheEntry struct {
+	key       string
+	resp      *SearchResponse
+	expiresAt time.Time
+}
+
+// MemoryCache 内存 LRU 缓存，超出容量时淘汰最久未使用的条目
+type MemoryCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// NewMemoryCache 创建内存缓存
+func NewMemoryCache(ttl time.Duration, maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Get 读取缓存，过期条目直接删除
+func (c *MemoryCache) Get(key string) (*SearchResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return copyResponse(entry.resp), true
+}
+
+// Set 写入缓存
+func (c *MemoryCache) Set(key string, resp *SearchResponse) {
+	c.setUntil(key, resp, time.Now().Add(c.ttl))
+}
+
+// setUntil 写入缓存并指定过期时间
+func (c *MemoryCache) setUntil(key string, resp *SearchResponse, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, resp: copyResponse(resp), expiresAt: expiresAt}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// diskEntry 磁盘缓存文件内容
+type diskEntry struct {
+	ExpiresAt time.Time       `json:"expires_at"`
+	Response  *SearchResponse `json:"response"`
+}
+
+// DiskCache 磁盘缓存，每个条目一个 JSON 文件，重启后仍然有效；前面有一层内存缓存
+type DiskCache struct {
+	dir        string
+	ttl        time.Duration
+	maxEntries int
+	memory     *MemoryCache
+
+	mu sync.Mutex
+}
+
+// NewDiskCache 创建磁盘缓存
+func NewDiskCache(dir string, ttl time.Duration, maxEntries int) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{
+		dir:        dir,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		memory:     NewMemoryCache(ttl, maxEntries),
+	}, nil
+}
+
+// path 返回缓存文件路径
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// Get 先查内存，未命中时读取磁盘文件
+func (c *DiskCache) Get(key string) (*SearchResponse, bool) {
+	if resp, ok := c.memory.Get(key); ok {
+		return resp, true
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Response == nil || time.Now().After(entry.ExpiresAt) {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+
+	c.memory.setUntil(key, entry.Response, entry.ExpiresAt)
+	return copyResponse(entry.Response), true
+}
+
+// Set 写入内存和磁盘，超出容量时删除最旧的文件
+func (c *DiskCache) Set(key string, resp *SearchResponse) {
+	expiresAt := time.Now().Add(c.ttl)
+	c.memory.setUntil(key, resp, expiresAt)
+
+	data, err := json.Marshal(diskEntry{ExpiresAt: expiresAt, Response: resp})
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 先写临时文件再重命名，避免读到不完整的文件
+	tmp := c.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		log.Printf("⚠️ Write cache file failed: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, c.path(key)); err != nil {
+		log.Printf("⚠️ Write cache file failed: %v", err)
+		os.Remove(tmp)
+		return
+	}
+
+	c.prune()
+}
+
+// prune 删除超出容量的最旧缓存文件（调用方需持有锁）
+func (c *DiskCache) prune() {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil || len(files) <= c.maxEntries {
+		return
+	}
+
+	type fileInfo struct {
+		path    string
+		modTime time.Time
+	}
+	infos := make([]fileInfo, 0, len(files))
+	for _, f := range files {
+		if stat, err := os.Stat(f); err == nil {
+			infos = append(infos, fileInfo{f, stat.ModTime()})
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].modTime.Before(infos[j].modTime)
+	})
+	if len(infos) <= c.maxEntries {
+		return
+	}
+
+	for _, info := range infos[:len(infos)-c.maxEntries] {
+		os.Remove(info.path)
+	}
+}
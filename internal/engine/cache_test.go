@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testResponse(title string) *SearchResponse {
+	return &SearchResponse{
+		Results:  []SearchResult{{Title: title, URL: "https://example.com/" + title}},
+		Warnings: []string{"warning"},
+	}
+}
+
+func TestMemoryCacheLRU(t *testing.T) {
+	c := NewMemoryCache(time.Hour, 2)
+	c.Set("a", testResponse("a"))
+	c.Set("b", testResponse("b"))
+
+	// 访问 a 后 b 成为最久未使用的条目
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("a missing")
+	}
+	c.Set("c", testResponse("c"))
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if resp, ok := c.Get(key); !ok || resp.Results[0].Title != key {
+			t.Errorf("Get(%q) = %v, %v", key, resp, ok)
+		}
+	}
+
+	// 覆盖已有条目不触发淘汰
+	c.Set("a", testResponse("a2"))
+	if resp, ok := c.Get("a"); !ok || resp.Results[0].Title != "a2" {
+		t.Errorf("Get(a) after overwrite = %v, %v", resp, ok)
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Error("overwrite evicted c")
+	}
+}
+
+func TestMemoryCacheTTL(t *testing.T) {
+	c := NewMemoryCache(time.Hour, 10)
+	c.setUntil("old", testResponse("old"), time.Now().Add(-time.Second))
+	c.Set("new", testResponse("new"))
+
+	if _, ok := c.Get("old"); ok {
+		t.Error("expired entry returned")
+	}
+	if _, ok := c.entries["old"]; ok {
+		t.Error("expired entry not removed")
+	}
+	if _, ok := c.Get("new"); !ok {
+		t.Error("fresh entry missing")
+	}
+}
+
+func TestMemoryCacheReturnsCopies(t *testing.T) {
+	c := NewMemoryCache(time.Hour, 10)
+	resp := testResponse("a")
+	c.Set("a", resp)
+
+	// 修改写入的响应和读出的响应都不影响缓存内容
+	resp.Results[0].Title = "changed"
+	got, _ := c.Get("a")
+	got.Results[0].Title = "changed again"
+	got.Warnings[0] = "changed"
+
+	got, _ = c.Get("a")
+	if got.Results[0].Title != "a" || got.Warnings[0] != "warning" {
+		t.Errorf("cached response was modified: %+v", got)
+	}
+}
+
+func TestDiskCachePersists(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, time.Hour, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("a", testResponse("a"))
+
+	// 新实例没有内存缓存，模拟重启后从磁盘读取
+	reopened, _ := NewDiskCache(dir, time.Hour, 10)
+	if resp, ok := reopened.Get("a"); !ok || resp.Results[0].Title != "a" {
+		t.Errorf("Get(a) after reopen = %+v, %v", resp, ok)
+	}
+	if _, ok := reopened.Get("missing"); ok {
+		t.Error("Get(missing) reported a hit")
+	}
+}
+
+func TestDiskCacheExpiry(t *testing.T) {
+	dir := t.TempDir()
+	c, _ := NewDiskCache(dir, -time.Second, 10)
+	c.Set("a", testResponse("a"))
+
+	reopened, _ := NewDiskCache(dir, time.Hour, 10)
+	if _, ok := reopened.Get("a"); ok {
+		t.Error("expired disk entry returned")
+	}
+	if _, err := os.Stat(reopened.path("a")); !os.IsNotExist(err) {
+		t.Errorf("expired cache file not removed: %v", err)
+	}
+}
+
+func TestDiskCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+	c, _ := NewDiskCache(dir, time.Hour, 2)
+
+	base := time.Now().Add(-time.Hour)
+	for i, key := range []string{"a", "b"} {
+		c.Set(key, testResponse(key))
+		// 显式设置修改时间，避免文件系统时间精度影响淘汰顺序
+		mtime := base.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(c.path(key), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Set("c", testResponse("c"))
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("cache files = %v, want 2", files)
+	}
+	if _, err := os.Stat(c.path("a")); !os.IsNotExist(err) {
+		t.Error("oldest cache file a was not removed")
+	}
+
+	reopened, _ := NewDiskCache(dir, time.Hour, 2)
+	for _, key := range []string{"b", "c"} {
+		if _, ok := reopened.Get(key); !ok {
+			t.Errorf("Get(%q) missing after eviction", key)
+		}
+	}
+}
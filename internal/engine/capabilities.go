@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/config"
+)
+
+// 引擎类别
+const (
+	CategoryWeb        = "web"
+	CategoryAcademic   = "academic"
+	CategoryDiscussion = "discussion"
+)
+
+// 引擎主要覆盖的地区
+const (
+	RegionGlobal = "global"
+	RegionCN     = "cn"
+)
+
+// Capabilities 引擎能力描述，由各引擎声明
+// Manager 据此校验请求，MCP 工具的引擎列表和描述也由此生成
+type Capabilities struct {
+	// Category 引擎类别：web、academic、discussion
+	Category string `json:"category"`
+	// Description 简短说明，用于工具描述
+	Description string `json:"description,omitempty"`
+	// Verticals 支持的搜索类型（web、news、images、videos）
+	Verticals []string `json:"verticals"`
+	// Filters 能原生处理的过滤条件（freshness、date_range、region、language、sort）
+	Filters []string `json:"filters"`
+	// MaxResults 单次网页搜索最多返回的结果数（多页合计），0 表示没有固定上限
+	MaxResults int `json:"max_results"`
+	// Pagination 是否支持 offset/cursor 翻页
+	Pagination bool `json:"pagination"`
+	// RequiresBrowser 需要本机安装 Chrome
+	RequiresBrowser bool `json:"requires_browser"`
+	// RegionFocus 主要覆盖的地区：global 或 cn
+	RegionFocus string `json:"region_focus"`
+}
+
+// EngineInfo 已注册引擎的能力和可用状态
+type EngineInfo struct {
+	Name string `json:"name"`
+	Capabilities
+	// Allowed 是否在 allowed_engines 中（未设置允许列表时都允许）
+	Allowed bool `json:"allowed"`
+	// Default 是否为默认引擎
+	Default bool `json:"default,omitempty"`
+	// InstantAnswer 是否能提供即时答案（实现了 InstantAnswerEngine）
+	InstantAnswer bool `json:"instant_answer,omitempty"`
+}
+
+// EngineInfos 返回已注册引擎的能力和可用状态，按配置中的引擎顺序排列
+func (m *Manager) EngineInfos() []EngineInfo {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	infos := make([]EngineInfo, 0, len(m.engines))
+	for name, engine := range m.engines {
+		_, instant := engine.(InstantAnswerEngine)
+		infos = append(infos, EngineInfo{
+			Name:          name,
+			Capabilities:  engine.Capabilities(),
+			Allowed:       m.config.IsEngineAllowed(name),
+			Default:       name == m.config.GetDefaultSearchEngine(),
+			InstantAnswer: instant,
+		})
+	}
+
+	order := make(map[string]int, len(config.ValidEngines))
+	for i, name := range config.ValidEngines {
+		order[name] = i
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		oi, iok := order[infos[i].Name]
+		oj, jok := order[infos[j].Name]
+		if iok != jok {
+			return iok
+		}
+		if oi != oj {
+			return oi < oj
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// supportsType 判断引擎是否支持该搜索类型
+func supportsType(engine SearchEngine, searchType string) bool {
+	return containsString(engine.Capabilities().Verticals, searchType)
+}
+
+// capabilityWarnings 按引擎声明的能力检查请求，返回需要提示调用方的警告（仍然执行搜索）
+func capabilityWarnings(engine SearchEngine, req SearchRequest, limit int) []string {
+	caps := engine.Capabilities()
+	var warnings []string
+	if caps.MaxResults > 0 && limit > caps.MaxResults {
+		warnings = append(warnings, fmt.Sprintf("engine %s returns at most %d results per search; limit %d not reachable from it alone", engine.Name(), caps.MaxResults, limit))
+	}
+	if req.Offset > 0 && !caps.Pagination {
+		warnings = append(warnings, fmt.Sprintf("engine %s does not support pagination; offset ignored", engine.Name()))
+	}
+	return warnings
+}
+
+// checkCapabilities 按所选引擎的能力校验请求：可用的引擎都不支持该搜索类型时返回错误，
+// 支持该类型的引擎都无法处理某个过滤条件时返回警告
+func (m *Manager) checkCapabilities(engines []string, req SearchRequest) ([]string, error) {
+	var runnable, typed []string
+	var filters [][]string
+	for _, name := range engines {
+		engine, ok := m.GetEngine(name)
+		if !ok || !m.config.IsEngineAllowed(name) {
+			continue
+		}
+		runnable = append(runnable, name)
+		if caps := engine.Capabilities(); containsString(caps.Verticals, req.Type) {
+			typed = append(typed, name)
+			filters = append(filters, caps.Filters)
+		}
+	}
+	// 没有可用引擎时由逐个引擎的检查报告原因
+	if len(runnable) == 0 {
+		return nil, nil
+	}
+
+	if len(typed) == 0 {
+		var supported []string
+		for _, info := range m.EngineInfos() {
+			if info.Allowed && containsString(info.Verticals, req.Type) {
+				supported = append(supported, info.Name)
+			}
+		}
+		return nil, fmt.Errorf("search type %s is not supported by engine(s) %v (supported by: %v)", req.Type, runnable, supported)
+	}
+
+	var warnings []string
+	for _, filter := range req.requestedFilters() {
+		honored := false
+		for _, f := range filters {
+			if containsString(f, filter) {
+				honored = true
+				break
+			}
+		}
+		if !honored {
+			warnings = append(warnings, fmt.Sprintf("no selected engine can honor filter %s; results are not filtered by it", filter))
+		}
+	}
+	return warnings, nil
+}
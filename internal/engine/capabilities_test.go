@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCheckCapabilities(t *testing.T) {
+	m := newTestManager(t, newStubEngine("bing", 3), newStubEngine("duckduckgo", 3))
+
+	// 所选引擎都不支持的搜索类型直接报错
+	_, err := m.Search(context.Background(), SearchRequest{
+		Query:         "go",
+		Engines:       []string{"bing", "duckduckgo"},
+		SearchOptions: SearchOptions{Type: SearchTypeNews},
+	})
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Fatalf("news search with web-only engines: err = %v", err)
+	}
+
+	// 所选引擎都无法处理的过滤条件给出汇总警告
+	resp, err := m.Search(context.Background(), SearchRequest{
+		Query:         "go",
+		Engines:       []string{"bing"},
+		SearchOptions: SearchOptions{Region: "de"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasWarning(resp.Warnings, "no selected engine can honor filter region") {
+		t.Errorf("warnings = %v, want a region warning", resp.Warnings)
+	}
+}
+
+func TestCapabilityWarnings(t *testing.T) {
+	baidu := NewBaiduEngine("", nil)
+	if w := capabilityWarnings(baidu, SearchRequest{}, 80); len(w) != 1 || !strings.Contains(w[0], "at most 50") {
+		t.Errorf("baidu limit 80 warnings = %v", w)
+	}
+	if w := capabilityWarnings(baidu, SearchRequest{}, 20); len(w) != 0 {
+		t.Errorf("baidu limit 20 warnings = %v, want none", w)
+	}
+}
@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// flight 一次正在进行的上游搜索，多个相同的请求共享结果
+type flight struct {
+	done    chan struct{}
+	results []SearchResult
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// Coalescer 合并并发的相同搜索：同一引擎、查询和参数只发起一次上游请求
+// 每个调用方的取消互不影响，所有调用方都离开后才取消上游请求
+type Coalescer struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// NewCoalescer 创建请求合并器
+func NewCoalescer() *Coalescer {
+	return &Coalescer{flights: make(map[string]*flight)}
+}
+
+// flightKey 由引擎、实际发送的查询、数量和过滤条件生成合并键
+func flightKey(engine, query string, limit int, opts SearchOptions) string {
+	data, _ := json.Marshal(struct {
+		Engine  string        `json:"e"`
+		Query   string        `json:"q"`
+		Limit   int           `json:"l"`
+		Options SearchOptions `json:"o"`
+	}{engine, query, limit, opts})
+	return string(data)
+}
+
+// Do 执行 fn，相同 key 的并发调用等待同一次执行的结果
+// fn 使用独立的上下文运行，只有当所有等待者都取消后才会被取消
+func (c *Coalescer) Do(ctx context.Context, key string, fn func(ctx context.Context) ([]SearchResult, error)) ([]SearchResult, error) {
+	c.mu.Lock()
+	f, ok := c.flights[key]
+	if ok {
+		f.waiters++
+	} else {
+		upstream, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		c.flights[key] = f
+
+		go func() {
+			f.results, f.err = fn(upstream)
+			cancel()
+
+			c.mu.Lock()
+			if c.flights[key] == f {
+				delete(c.flights, key)
+			}
+			c.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return nil, f.err
+		}
+		// 每个调用方拿到独立的副本，后续解析跳转链接、过滤时互不影响
+		return append([]SearchResult(nil), f.results...), nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			// 已取消的请求不再接收新的等待者
+			if c.flights[key] == f {
+				delete(c.flights, key)
+			}
+		}
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
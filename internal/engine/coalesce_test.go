@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitFlight 等待 key 对应的请求达到指定的等待者数量
+func waitFlight(t *testing.T, c *Coalescer, key string, waiters int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		f := c.flights[key]
+		n := 0
+		if f != nil {
+			n = f.waiters
+		}
+		c.mu.Unlock()
+		if n == waiters {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("flight %q did not reach %d waiters", key, waiters)
+}
+
+func TestCoalescerSharesResult(t *testing.T) {
+	c := NewCoalescer()
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	fn := func(ctx context.Context) ([]SearchResult, error) {
+		calls.Add(1)
+		<-release
+		return []SearchResult{{Title: "shared"}}, nil
+	}
+
+	const n = 5
+	var wg sync.WaitGroup
+	results := make([][]SearchResult, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = c.Do(context.Background(), "k", fn)
+		}(i)
+	}
+	waitFlight(t, c, "k", n)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fn called %d times, want 1", got)
+	}
+	for i, r := range results {
+		if len(r) != 1 || r[0].Title != "shared" {
+			t.Fatalf("caller %d got %+v", i, r)
+		}
+	}
+	// 每个调用方拿到独立的副本
+	results[0][0].Title = "changed"
+	if results[1][0].Title != "shared" {
+		t.Error("callers share the same result slice")
+	}
+
+	// 请求完成后不再合并，新的调用重新执行
+	c.Do(context.Background(), "k", func(ctx context.Context) ([]SearchResult, error) {
+		calls.Add(1)
+		return nil, nil
+	})
+	if got := calls.Load(); got != 2 {
+		t.Errorf("fn called %d times after completion, want 2", got)
+	}
+}
+
+func TestCoalescerSharesError(t *testing.T) {
+	c := NewCoalescer()
+	want := errors.New("upstream failed")
+	if _, err := c.Do(context.Background(), "k", func(ctx context.Context) ([]SearchResult, error) {
+		return nil, want
+	}); !errors.Is(err, want) {
+		t.Errorf("Do() error = %v, want %v", err, want)
+	}
+}
+
+func TestCoalescerWaiterCancel(t *testing.T) {
+	c := NewCoalescer()
+	release := make(chan struct{})
+	upstreamErr := make(chan error, 1)
+
+	fn := func(ctx context.Context) ([]SearchResult, error) {
+		select {
+		case <-release:
+			upstreamErr <- nil
+			return []SearchResult{{Title: "ok"}}, nil
+		case <-ctx.Done():
+			upstreamErr <- ctx.Err()
+			return nil, ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := c.Do(ctx, "k", fn)
+		canceled <- err
+	}()
+	waitFlight(t, c, "k", 1)
+
+	done := make(chan []SearchResult, 1)
+	go func() {
+		r, _ := c.Do(context.Background(), "k", fn)
+		done <- r
+	}()
+	waitFlight(t, c, "k", 2)
+
+	// 一个等待者取消不影响其他等待者和上游请求
+	cancel()
+	if err := <-canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled waiter error = %v, want context.Canceled", err)
+	}
+	waitFlight(t, c, "k", 1)
+
+	close(release)
+	if r := <-done; len(r) != 1 {
+		t.Errorf("remaining waiter got %+v", r)
+	}
+	if err := <-upstreamErr; err != nil {
+		t.Errorf("upstream canceled: %v", err)
+	}
+}
+
+func TestCoalescerAllWaitersCancel(t *testing.T) {
+	c := NewCoalescer()
+	upstreamErr := make(chan error, 1)
+	fn := func(ctx context.Context) ([]SearchResult, error) {
+		<-ctx.Done()
+		upstreamErr <- ctx.Err()
+		return nil, ctx.Err()
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() { _, err := c.Do(ctx1, "k", fn); errs <- err }()
+	waitFlight(t, c, "k", 1)
+	go func() { _, err := c.Do(ctx2, "k", fn); errs <- err }()
+	waitFlight(t, c, "k", 2)
+
+	cancel1()
+	<-errs
+	select {
+	case err := <-upstreamErr:
+		t.Fatalf("upstream canceled while a waiter remains: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// 最后一个等待者离开后取消上游请求，并且不再接收新的等待者
+	cancel2()
+	<-errs
+	select {
+	case err := <-upstreamErr:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("upstream error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("upstream not canceled after all waiters left")
+	}
+
+	c.mu.Lock()
+	_, ok := c.flights["k"]
+	c.mu.Unlock()
+	if ok {
+		t.Error("canceled flight still accepts waiters")
+	}
+}
@@ -0,0 +1,221 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CrossrefEngine Crossref 文献元数据搜索引擎实现
+type CrossrefEngine struct {
+	client   *http.Client
+	proxyURL string
+}
+
+// NewCrossrefEngine 创建 Crossref 搜索引擎实例
+func NewCrossrefEngine(proxyURL string, limiter *RateLimiter) *CrossrefEngine {
+	transport := &http.Transport{}
+	if proxyURL != "" {
+		if proxy, err := url.Parse(proxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+
+	client := &http.Client{
+		Transport: newRateLimitedTransport(limiter, transport),
+	}
+
+	return &CrossrefEngine{
+		client:   client,
+		proxyURL: proxyURL,
+	}
+}
+
+// Name 返回引擎名称
+func (e *CrossrefEngine) Name() string {
+	return "crossref"
+}
+
+// Capabilities 返回引擎能力描述
+func (e *CrossrefEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryAcademic,
+		Description: "Crossref journal and conference metadata",
+		Verticals:   webOnly,
+		Filters:     []string{FilterFreshness, FilterDateRange, FilterSort},
+		MaxResults:  100,
+		Pagination:  true,
+		RegionFocus: RegionGlobal,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符（Crossref 为书目检索，不支持操作符）
+func (e *CrossrefEngine) QueryDialect() QueryDialect {
+	return QueryDialect{}
+}
+
+// crossrefResponse Crossref works 接口响应
+type crossrefResponse struct {
+	Status  string `json:"status"`
+	Message struct {
+		Items []crossrefItem `json:"items"`
+	} `json:"message"`
+}
+
+// crossrefItem Crossref 单条作品
+type crossrefItem struct {
+	DOI            string   `json:"DOI"`
+	URL            string   `json:"URL"`
+	Title          []string `json:"title"`
+	ContainerTitle []string `json:"container-title"`
+	Publisher      string   `json:"publisher"`
+	Abstract       string   `json:"abstract"`
+	Author         []struct {
+		Given  string `json:"given"`
+		Family string `json:"family"`
+		Name   string `json:"name"`
+	} `json:"author"`
+	Issued crossrefDate `json:"issued"`
+	Link   []struct {
+		URL         string `json:"URL"`
+		ContentType string `json:"content-type"`
+	} `json:"link"`
+}
+
+// crossrefDate Crossref 日期结构
+type crossrefDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// Search 执行 Crossref 搜索
+func (e *CrossrefEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	rows := limit
+	if rows > 100 {
+		rows = 100
+	}
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("rows", fmt.Sprintf("%d", rows))
+	if opts.Offset > 0 {
+		params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+	}
+	params.Set("select", "DOI,URL,title,container-title,publisher,abstract,author,issued,link")
+	if from, to, ok := opts.timeRange(time.Now()); ok {
+		params.Set("filter", fmt.Sprintf("from-pub-date:%s,until-pub-date:%s", from.Format(dateLayout), to.Format(dateLayout)))
+	}
+	if opts.Sort == SortDate {
+		params.Set("sort", "published")
+		params.Set("order", "desc")
+	}
+
+	searchURL := fmt.Sprintf("https://api.crossref.org/works?%s", params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	// Crossref 建议在 User-Agent 中标明客户端，以进入 polite pool
+	req.Header.Set("User-Agent", "go-web-search-mcp/1.0 (https://github.com/cliffyan/go-web-search-mcp)")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	var data crossrefResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("parse JSON failed: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(data.Message.Items))
+	for _, item := range data.Message.Items {
+		if result := e.parseItem(item); result != nil {
+			results = append(results, *result)
+		}
+	}
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	log.Printf("🔍 Crossref: found %d results for query '%s'", len(results), query)
+	return results, nil
+}
+
+// parseItem 解析单条作品
+func (e *CrossrefEngine) parseItem(item crossrefItem) *SearchResult {
+	if len(item.Title) == 0 {
+		return nil
+	}
+	title := cleanText(item.Title[0])
+	if title == "" {
+		return nil
+	}
+
+	href := item.URL
+	if href == "" && item.DOI != "" {
+		href = "https://doi.org/" + item.DOI
+	}
+	if href == "" {
+		return nil
+	}
+
+	authors := make([]string, 0, len(item.Author))
+	for _, a := range item.Author {
+		name := strings.TrimSpace(strings.TrimSpace(a.Given) + " " + strings.TrimSpace(a.Family))
+		if name == "" {
+			name = strings.TrimSpace(a.Name)
+		}
+		if name != "" {
+			authors = append(authors, name)
+		}
+	}
+
+	venue := ""
+	if len(item.ContainerTitle) > 0 {
+		venue = cleanText(item.ContainerTitle[0])
+	}
+
+	year := 0
+	if len(item.Issued.DateParts) > 0 && len(item.Issued.DateParts[0]) > 0 {
+		year = item.Issued.DateParts[0][0]
+	}
+
+	pdfURL := ""
+	for _, link := range item.Link {
+		if link.ContentType == "application/pdf" {
+			pdfURL = link.URL
+			break
+		}
+	}
+
+	// 摘要为 JATS XML，去除标签
+	description := truncateText(cleanText(item.Abstract), 500)
+
+	return &SearchResult{
+		Title:       title,
+		URL:         href,
+		Description: description,
+		Source:      item.Publisher,
+		Engine:      "crossref",
+		Authors:     authors,
+		Venue:       venue,
+		Year:        year,
+		DOI:         item.DOI,
+		PDFURL:      pdfURL,
+	}
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCrossrefSearch(t *testing.T) {
+	var query string
+	e := &CrossrefEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		query = req.URL.RawQuery
+		return http.StatusOK, readFixture(t, "crossref.json")
+	})}
+
+	results, err := e.Search(context.Background(), "human ai interaction", 5, SearchOptions{Offset: 10, Sort: SortDate})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := queryParams(t, query)
+	if params.Get("query") != "human ai interaction" || params.Get("rows") != "5" || params.Get("offset") != "10" || params.Get("sort") != "published" {
+		t.Errorf("request params = %v", params)
+	}
+
+	// 没有标题的作品被跳过
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	want := SearchResult{
+		Title:       "Guidelines for Human-AI Interaction",
+		URL:         "https://dx.doi.org/10.1145/3290605.3300233",
+		Description: "Advances in artificial intelligence.",
+		Source:      "ACM",
+		Engine:      "crossref",
+		Authors:     []string{"Saleema Amershi", "CHI Working Group"},
+		Venue:       "Proceedings of the 2019 CHI Conference",
+		Year:        2019,
+		DOI:         "10.1145/3290605.3300233",
+		PDFURL:      "https://dl.acm.org/doi/pdf/10.1145/3290605.3300233",
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+
+	// 没有 URL 时使用 DOI 链接，日期缺失时年份为 0
+	if r := results[1]; r.URL != "https://doi.org/10.1000/no-url" || r.Year != 0 {
+		t.Errorf("results[1] = %+v", r)
+	}
+}
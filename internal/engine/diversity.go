@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// DomainGroup 同一域名下的结果
+type DomainGroup struct {
+	Domain string `json:"domain"`
+	Count  int    `json:"count"`
+	// Positions 该域名的结果在 results 中的位置（从 1 开始）
+	Positions []int `json:"positions"`
+}
+
+// domainOf 返回链接的可注册域名（如 blog.example.co.uk -> example.co.uk），用于按来源分组
+func domainOf(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+	return host
+}
+
+// capPerDomain 每个域名最多保留 max 条结果（按现有顺序），返回保留的结果和被去掉的数量
+func capPerDomain(results []SearchResult, max int) ([]SearchResult, int) {
+	if max <= 0 {
+		return results, 0
+	}
+
+	counts := make(map[string]int)
+	kept := results[:0:0]
+	for _, r := range results {
+		domain := domainOf(r.URL)
+		if counts[domain] >= max {
+			continue
+		}
+		counts[domain]++
+		kept = append(kept, r)
+	}
+	return kept, len(results) - len(kept)
+}
+
+// groupByDomain 按域名分组，分组顺序为各域名首次出现的顺序
+func groupByDomain(results []SearchResult) []DomainGroup {
+	var groups []DomainGroup
+	index := make(map[string]int)
+
+	for i, r := range results {
+		domain := domainOf(r.URL)
+		pos, ok := index[domain]
+		if !ok {
+			pos = len(groups)
+			index[domain] = pos
+			groups = append(groups, DomainGroup{Domain: domain})
+		}
+		groups[pos].Count++
+		groups[pos].Positions = append(groups[pos].Positions, i+1)
+	}
+	return groups
+}
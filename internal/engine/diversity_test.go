@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+// resultsAt 按链接创建测试结果
+func resultsAt(links ...string) []SearchResult {
+	results := make([]SearchResult, len(links))
+	for i, link := range links {
+		results[i] = SearchResult{Title: link, URL: link}
+	}
+	return results
+}
+
+// urlsOf 返回结果的链接列表
+func urlsOf(results []SearchResult) []string {
+	urls := make([]string, len(results))
+	for i, r := range results {
+		urls[i] = r.URL
+	}
+	return urls
+}
+
+func TestDomainOf(t *testing.T) {
+	tests := map[string]string{
+		"https://www.example.com/a":       "example.com",
+		"https://blog.example.co.uk/post": "example.co.uk",
+		"https://GO.DEV/doc":              "go.dev",
+		"not a url":                       "",
+	}
+	for link, want := range tests {
+		if got := domainOf(link); got != want {
+			t.Errorf("domainOf(%q) = %q, want %q", link, got, want)
+		}
+	}
+}
+
+func TestCapPerDomain(t *testing.T) {
+	results := resultsAt(
+		"https://a.com/1",
+		"https://www.a.com/2",
+		"https://b.com/1",
+		"https://docs.a.com/3",
+		"https://b.com/2",
+		"https://c.com/1",
+	)
+
+	tests := []struct {
+		name        string
+		max         int
+		want        []string
+		wantDropped int
+	}{
+		{"unlimited", 0, urlsOf(results), 0},
+		{"negative is unlimited", -1, urlsOf(results), 0},
+		{"one per domain", 1, []string{"https://a.com/1", "https://b.com/1", "https://c.com/1"}, 3},
+		{"two per domain keeps order", 2, []string{
+			"https://a.com/1", "https://www.a.com/2", "https://b.com/1", "https://b.com/2", "https://c.com/1",
+		}, 1},
+		{"cap above counts", 5, urlsOf(results), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, dropped := capPerDomain(results, tt.max)
+			if got := urlsOf(kept); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("capPerDomain(%d) = %v, want %v", tt.max, got, tt.want)
+			}
+			if dropped != tt.wantDropped {
+				t.Errorf("capPerDomain(%d) dropped = %d, want %d", tt.max, dropped, tt.wantDropped)
+			}
+		})
+	}
+
+	// 不修改调用方的切片
+	if got := urlsOf(results); got[1] != "https://www.a.com/2" {
+		t.Errorf("capPerDomain modified its input: %v", got)
+	}
+}
+
+func TestGroupByDomain(t *testing.T) {
+	results := resultsAt(
+		"https://b.com/1",
+		"https://a.com/1",
+		"https://docs.b.com/2",
+		"https://c.com/1",
+		"https://www.a.com/2",
+	)
+
+	want := []DomainGroup{
+		{Domain: "b.com", Count: 2, Positions: []int{1, 3}},
+		{Domain: "a.com", Count: 2, Positions: []int{2, 5}},
+		{Domain: "c.com", Count: 1, Positions: []int{4}},
+	}
+	if got := groupByDomain(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("groupByDomain() = %+v, want %+v", got, want)
+	}
+	if got := groupByDomain(nil); got != nil {
+		t.Errorf("groupByDomain(nil) = %+v, want nil", got)
+	}
+}
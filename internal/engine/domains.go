@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// DomainRules 域名规则：屏蔽、仅允许和排名权重
+// 规则写法：example.com 匹配该域名及其子域名；*.example.com 只匹配子域名；其他含 * 的规则按通配符匹配整个域名
+type DomainRules struct {
+	Block []string `json:"block,omitempty"`
+	// Allow 非空时只保留匹配的域名
+	Allow []string `json:"allow,omitempty"`
+	// Boost 排名权重，大于 1 提升、小于 1 降低
+	Boost map[string]float64 `json:"boost,omitempty"`
+}
+
+// IsEmpty 判断是否没有任何规则
+func (r DomainRules) IsEmpty() bool {
+	return len(r.Block) == 0 && len(r.Allow) == 0 && len(r.Boost) == 0
+}
+
+// Merge 合并单次调用的规则：屏蔽规则叠加，仅允许规则和同名权重以调用方为准
+func (r DomainRules) Merge(call DomainRules) DomainRules {
+	merged := DomainRules{
+		Block: append(append([]string(nil), r.Block...), call.Block...),
+		Allow: r.Allow,
+		Boost: make(map[string]float64, len(r.Boost)+len(call.Boost)),
+	}
+	if len(call.Allow) > 0 {
+		merged.Allow = call.Allow
+	}
+	for pattern, weight := range r.Boost {
+		merged.Boost[pattern] = weight
+	}
+	for pattern, weight := range call.Boost {
+		merged.Boost[pattern] = weight
+	}
+	return merged
+}
+
+// matchDomain 判断域名是否匹配规则
+func matchDomain(pattern, host string) bool {
+	pattern = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(pattern)), "www.")
+	if pattern == "" {
+		return false
+	}
+	if strings.Contains(pattern, "*") {
+		ok, _ := path.Match(pattern, host)
+		return ok
+	}
+	return host == pattern || strings.HasSuffix(host, "."+pattern)
+}
+
+// matchAnyDomain 判断域名是否匹配任一规则
+func matchAnyDomain(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if matchDomain(pattern, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// resultHost 返回结果的域名（小写、去掉 www.）
+func resultHost(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// allows 判断链接是否通过屏蔽和允许列表
+func (r DomainRules) allows(link string) bool {
+	host := resultHost(link)
+	if matchAnyDomain(r.Block, host) {
+		return false
+	}
+	return len(r.Allow) == 0 || matchAnyDomain(r.Allow, host)
+}
+
+// weight 返回结果的排名权重，多条规则匹配时取最具体（最长）的规则
+func (r DomainRules) weight(link string) float64 {
+	host := resultHost(link)
+	best, weight := "", 1.0
+	for pattern, w := range r.Boost {
+		if matchDomain(pattern, host) && len(pattern) > len(best) {
+			best, weight = pattern, w
+		}
+	}
+	return weight
+}
+
+// ApplyBoosts 按域名权重调整融合得分
+func (r DomainRules) ApplyBoosts(results []SearchResult) {
+	if len(r.Boost) == 0 {
+		return
+	}
+	for i := range results {
+		results[i].RRFScore *= r.weight(results[i].URL)
+	}
+}
@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchDomain(t *testing.T) {
+	tests := []struct {
+		pattern, host string
+		want          bool
+	}{
+		// 精确匹配与子域名
+		{"example.com", "example.com", true},
+		{"example.com", "docs.example.com", true},
+		{"example.com", "a.b.example.com", true},
+		{"example.com", "notexample.com", false},
+		{"example.com", "example.com.evil.net", false},
+		// 规则大小写、空白和 www. 前缀不影响匹配
+		{" WWW.Example.com ", "example.com", true},
+		// *.example.com 只匹配子域名
+		{"*.example.com", "docs.example.com", true},
+		{"*.example.com", "example.com", false},
+		// 其他通配符匹配整个域名
+		{"*.gov", "usa.gov", true},
+		{"*.gov", "usa.gov.cn", false},
+		{"example.*", "example.org", true},
+		{"blog*.example.com", "blog2.example.com", true},
+		{"blog*.example.com", "docs.example.com", false},
+		// 空规则不匹配任何域名
+		{"", "example.com", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchDomain(tt.pattern, tt.host); got != tt.want {
+			t.Errorf("matchDomain(%q, %q) = %v, want %v", tt.pattern, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestDomainRulesAllows(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules DomainRules
+		link  string
+		want  bool
+	}{
+		{"no rules", DomainRules{}, "https://example.com/", true},
+		{"blocked", DomainRules{Block: []string{"example.com"}}, "https://www.example.com/a", false},
+		{"blocked subdomain", DomainRules{Block: []string{"example.com"}}, "https://docs.example.com/a", false},
+		{"not blocked", DomainRules{Block: []string{"example.com"}}, "https://go.dev/", true},
+		{"allow list only, allowed", DomainRules{Allow: []string{"go.dev"}}, "https://go.dev/doc", true},
+		{"allow list only, not allowed", DomainRules{Allow: []string{"go.dev"}}, "https://example.com/", false},
+		{"block wins over allow", DomainRules{Allow: []string{"*.go.dev"}, Block: []string{"pkg.go.dev"}}, "https://pkg.go.dev/net", false},
+		{"unparsable link with allow list", DomainRules{Allow: []string{"go.dev"}}, "://bad", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.allows(tt.link); got != tt.want {
+				t.Errorf("allows(%q) = %v, want %v", tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainRulesWeight(t *testing.T) {
+	rules := DomainRules{Boost: map[string]float64{
+		"example.com":      2,
+		"docs.example.com": 0.5,
+		"*.gov":            3,
+	}}
+
+	tests := map[string]float64{
+		"https://example.com/":          2,
+		"https://blog.example.com/":     2,
+		"https://docs.example.com/page": 0.5,
+		"https://usa.gov/":              3,
+		"https://go.dev/":               1,
+	}
+	for link, want := range tests {
+		if got := rules.weight(link); got != want {
+			t.Errorf("weight(%q) = %v, want %v", link, got, want)
+		}
+	}
+}
+
+func TestApplyBoosts(t *testing.T) {
+	results := []SearchResult{
+		{URL: "https://example.com/", RRFScore: 0.1},
+		{URL: "https://spam.net/", RRFScore: 0.2},
+		{URL: "https://go.dev/", RRFScore: 0.3},
+	}
+	DomainRules{Boost: map[string]float64{"example.com": 3, "spam.net": 0.5}}.ApplyBoosts(results)
+
+	var got []float64
+	for _, r := range results {
+		got = append(got, r.RRFScore)
+	}
+	want := []float64{0.1 * 3, 0.2 * 0.5, 0.3}
+	for i := range want {
+		if diff := got[i] - want[i]; diff > 1e-12 || diff < -1e-12 {
+			t.Errorf("scores = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestDomainRulesMerge(t *testing.T) {
+	global := DomainRules{
+		Block: []string{"spam.net"},
+		Allow: []string{"example.com"},
+		Boost: map[string]float64{"example.com": 2, "go.dev": 1.5},
+	}
+	call := DomainRules{
+		Block: []string{"ads.example.com"},
+		Allow: []string{"go.dev"},
+		Boost: map[string]float64{"example.com": 0.5},
+	}
+
+	want := DomainRules{
+		Block: []string{"spam.net", "ads.example.com"},
+		Allow: []string{"go.dev"},
+		Boost: map[string]float64{"example.com": 0.5, "go.dev": 1.5},
+	}
+	if got := global.Merge(call); !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+	if got := global.Merge(DomainRules{}); !reflect.DeepEqual(got.Allow, global.Allow) {
+		t.Errorf("Merge(empty).Allow = %v, want the global allow list", got.Allow)
+	}
+	if len(global.Block) != 1 {
+		t.Errorf("Merge modified the global rules: %+v", global)
+	}
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -22,7 +23,7 @@ type DuckDuckGoEngine struct {
 }
 
 // NewDuckDuckGoEngine 创建 DuckDuckGo 搜索引擎实例
-func NewDuckDuckGoEngine(proxyURL string) *DuckDuckGoEngine {
+func NewDuckDuckGoEngine(proxyURL string, limiter *RateLimiter) *DuckDuckGoEngine {
 	jar, _ := cookiejar.New(nil)
 	
 	transport := &http.Transport{}
@@ -33,9 +34,8 @@ func NewDuckDuckGoEngine(proxyURL string) *DuckDuckGoEngine {
 	}
 
 	client := &http.Client{
-		Timeout:   30 * time.Second,
 		Jar:       jar,
-		Transport: transport,
+		Transport: newRateLimitedTransport(limiter, transport),
 	}
 
 	return &DuckDuckGoEngine{
@@ -49,17 +49,121 @@ func (e *DuckDuckGoEngine) Name() string {
 	return "duckduckgo"
 }
 
+// Capabilities 返回引擎能力描述
+func (e *DuckDuckGoEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryWeb,
+		Description: "DuckDuckGo HTML and lite pages",
+		Verticals:   SearchTypes,
+		Filters:     []string{FilterFreshness, FilterDateRange, FilterRegion, FilterLanguage},
+		MaxResults:  50,
+		Pagination:  true,
+		RegionFocus: RegionGlobal,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符
+func (e *DuckDuckGoEngine) QueryDialect() QueryDialect {
+	return standardDialect
+}
+
 // Search 执行 DuckDuckGo 搜索
-func (e *DuckDuckGoEngine) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
-	// DuckDuckGo HTML 版本
-	searchURL := fmt.Sprintf("https://html.duckduckgo.com/html/?q=%s", url.QueryEscape(query))
+func (e *DuckDuckGoEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	// 新闻、图片、视频走 JSON 接口
+	if opts.Type != "" && opts.Type != SearchTypeWeb {
+		return e.searchVertical(ctx, query, limit, opts)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	results, err := e.searchWeb(ctx, query, limit, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔍 DuckDuckGo: found %d results for query '%s'", len(results), query)
+	return results, nil
+}
+
+// DuckDuckGo 网页搜索入口：html 版本为主，触发人机验证时切换到 lite 版本
+const (
+	duckDuckGoHTMLURL = "https://html.duckduckgo.com/html/"
+	duckDuckGoLiteURL = "https://lite.duckduckgo.com/lite/"
+)
+
+// errDuckDuckGoChallenge html 版本返回了人机验证页面
+var errDuckDuckGoChallenge = errors.New("duckduckgo challenge page")
+
+// searchWeb 按“下一页”表单（s/dc/vqd 等字段）逐页 POST 抓取网页结果
+func (e *DuckDuckGoEngine) searchWeb(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	form := url.Values{}
+	form.Set("q", query)
+	form.Set("b", "")
+	if kl := duckDuckGoLocale(opts); kl != "" {
+		form.Set("kl", kl)
+	}
+	if df := duckDuckGoTimeFilter(opts); df != "" {
+		form.Set("df", df)
+	}
+	if opts.Offset > 0 {
+		form.Set("s", fmt.Sprintf("%d", opts.Offset))
+		form.Set("dc", fmt.Sprintf("%d", opts.Offset+1))
+	}
+
+	endpoint := duckDuckGoHTMLURL
+	var allResults []SearchResult
+
+	for page := 0; len(allResults) < limit && page < 5; page++ {
+		doc, err := e.postForm(ctx, endpoint, form, opts)
+		if errors.Is(err, errDuckDuckGoChallenge) && endpoint == duckDuckGoHTMLURL {
+			// 用同一份表单在 lite 版本重试当前页
+			log.Printf("⚠️ DuckDuckGo: html endpoint returned a challenge page, falling back to lite")
+			endpoint = duckDuckGoLiteURL
+			doc, err = e.postForm(ctx, endpoint, form, opts)
+		}
+		if err != nil {
+			if len(allResults) > 0 {
+				log.Printf("⚠️ DuckDuckGo: Error on page %d, returning %d results collected so far: %v", page+1, len(allResults), err)
+				break
+			}
+			return nil, err
+		}
+
+		var results []SearchResult
+		if endpoint == duckDuckGoLiteURL {
+			results = e.parseLiteResults(doc)
+		} else {
+			results = e.parseResults(doc, limit)
+		}
+		if len(results) == 0 {
+			break
+		}
+		allResults = append(allResults, results...)
+
+		// 下一页表单携带 s、dc、vqd 等隐藏字段，没有表单说明已是最后一页
+		next := duckDuckGoNextForm(doc)
+		if next == nil {
+			break
+		}
+		form = next
+	}
+
+	if len(allResults) > limit {
+		allResults = allResults[:limit]
+	}
+
+	return allResults, nil
+}
+
+// postForm 提交搜索表单并解析返回页面，检测人机验证
+func (e *DuckDuckGoEngine) postForm(ctx context.Context, endpoint string, form url.Values, opts SearchOptions) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("create request failed: %w", err)
 	}
 
 	e.setHeaders(req)
+	req.Header.Set("Accept-Language", acceptLanguage(opts.Language, "en-US,en;q=0.9"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Referer", endpoint)
 
 	resp, err := e.client.Do(req)
 	if err != nil {
@@ -67,25 +171,112 @@ func (e *DuckDuckGoEngine) Search(ctx context.Context, query string, limit int)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("read body failed: %w", err)
 	}
 
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	// 人机验证页面通常以 202 返回，或包含 anomaly/challenge 表单
+	bodyStr := string(body)
+	if resp.StatusCode == http.StatusAccepted ||
+		strings.Contains(bodyStr, "anomaly-modal") ||
+		strings.Contains(bodyStr, "challenge-form") {
+		return nil, errDuckDuckGoChallenge
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(bodyStr))
 	if err != nil {
 		return nil, fmt.Errorf("parse HTML failed: %w", err)
 	}
 
-	results := e.parseResults(doc, limit)
-	log.Printf("🔍 DuckDuckGo: found %d results for query '%s'", len(results), query)
+	return doc, nil
+}
 
-	return results, nil
+// duckDuckGoNextForm 提取“下一页”表单的字段，html 与 lite 版本通用
+func duckDuckGoNextForm(doc *goquery.Document) url.Values {
+	var next url.Values
+
+	doc.Find("form").EachWithBreak(func(i int, f *goquery.Selection) bool {
+		submit := f.Find(`input[type="submit"]`).First()
+		if value, _ := submit.Attr("value"); !strings.HasPrefix(value, "Next") {
+			return true
+		}
+
+		next = url.Values{}
+		f.Find("input[name]").Each(func(j int, input *goquery.Selection) {
+			name, _ := input.Attr("name")
+			value, _ := input.Attr("value")
+			if t, _ := input.Attr("type"); t != "submit" {
+				next.Set(name, value)
+			}
+		})
+		return false
+	})
+
+	if next.Get("q") == "" {
+		return nil
+	}
+	return next
+}
+
+// duckDuckGoRegionLanguages 地区对应的默认语言，用于拼接 kl 参数（如 us-en、de-de）
+var duckDuckGoRegionLanguages = map[string]string{
+	"us": "en", "uk": "en", "gb": "en", "au": "en", "ca": "en", "in": "en", "ie": "en", "nz": "en",
+	"cn": "zh", "tw": "tzh", "hk": "tzh", "jp": "jp", "kr": "kr",
+}
+
+// duckDuckGoLanguageRegions 仅指定语言时对应的默认地区
+var duckDuckGoLanguageRegions = map[string]string{
+	"en": "us", "zh": "cn", "ja": "jp", "ko": "kr", "de": "de", "fr": "fr", "es": "es",
+	"it": "it", "pt": "pt", "ru": "ru", "nl": "nl", "pl": "pl", "sv": "se", "tr": "tr",
+}
+
+// duckDuckGoLocale 根据地区和语言生成 kl 参数
+func duckDuckGoLocale(opts SearchOptions) string {
+	region := opts.Region
+	if region == "" && opts.Language != "" {
+		region = duckDuckGoLanguageRegions[opts.Language]
+		if region == "" {
+			region = opts.Language
+		}
+	}
+	if region == "" {
+		return ""
+	}
+
+	language := opts.Language
+	if language == "" || duckDuckGoRegionLanguages[region] != "" {
+		language = duckDuckGoRegionLanguages[region]
+	}
+	if language == "" {
+		language = region
+	}
+	if region == "gb" {
+		region = "uk"
+	}
+	return region + "-" + language
+}
+
+// duckDuckGoTimeFilter 生成时间过滤参数 df：d/w/m/y 或 YYYY-MM-DD..YYYY-MM-DD
+func duckDuckGoTimeFilter(opts SearchOptions) string {
+	switch opts.Freshness {
+	case FreshnessDay:
+		return "d"
+	case FreshnessWeek:
+		return "w"
+	case FreshnessMonth:
+		return "m"
+	case FreshnessYear:
+		return "y"
+	case FreshnessCustom:
+		from, to, _ := opts.timeRange(time.Now())
+		return from.Format(dateLayout) + ".." + to.Format(dateLayout)
+	}
+	return ""
 }
 
 // setHeaders 设置请求头
@@ -119,14 +310,7 @@ func (e *DuckDuckGoEngine) parseResults(doc *goquery.Document, limit int) []Sear
 			return
 		}
 
-		// DuckDuckGo 的链接可能是相对的或者经过编码的
-		if strings.HasPrefix(href, "//duckduckgo.com/l/") {
-			// 解析重定向 URL
-			if parsed, err := url.Parse("https:" + href); err == nil {
-				href = parsed.Query().Get("uddg")
-			}
-		}
-
+		href = duckDuckGoUnwrapURL(href)
 		if href == "" || !strings.HasPrefix(href, "http") {
 			return
 		}
@@ -157,21 +341,83 @@ func (e *DuckDuckGoEngine) parseResults(doc *goquery.Document, limit int) []Sear
 	return results
 }
 
-// DuckDuckGoInstantAnswer 使用 DuckDuckGo Instant Answer API（备用方案）
+// parseLiteResults 解析 lite 版本的表格结果：标题行、摘要行、链接行依次排列
+func (e *DuckDuckGoEngine) parseLiteResults(doc *goquery.Document) []SearchResult {
+	var results []SearchResult
+
+	doc.Find("a.result-link").Each(func(i int, s *goquery.Selection) {
+		row := s.Closest("tr")
+		if row.HasClass("result-sponsored") {
+			return
+		}
+
+		href, _ := s.Attr("href")
+		href = duckDuckGoUnwrapURL(href)
+		title := strings.TrimSpace(s.Text())
+		if href == "" || title == "" || !strings.HasPrefix(href, "http") {
+			return
+		}
+
+		description := strings.TrimSpace(row.Next().Find("td.result-snippet").Text())
+		source := strings.TrimSpace(row.Next().Next().Find("span.link-text").Text())
+
+		results = append(results, SearchResult{
+			Title:       title,
+			URL:         href,
+			Description: description,
+			Source:      source,
+			Engine:      "duckduckgo",
+		})
+	})
+
+	return results
+}
+
+// duckDuckGoUnwrapURL 还原 //duckduckgo.com/l/?uddg= 形式的跳转链接
+func duckDuckGoUnwrapURL(href string) string {
+	if strings.HasPrefix(href, "//duckduckgo.com/l/") || strings.HasPrefix(href, "https://duckduckgo.com/l/") {
+		if parsed, err := url.Parse(href); err == nil {
+			return parsed.Query().Get("uddg")
+		}
+	}
+	return href
+}
+
+// DuckDuckGoInstantAnswer Instant Answer API 响应
 type DuckDuckGoInstantAnswer struct {
-	Abstract       string `json:"Abstract"`
-	AbstractText   string `json:"AbstractText"`
-	AbstractSource string `json:"AbstractSource"`
-	AbstractURL    string `json:"AbstractURL"`
-	RelatedTopics  []struct {
-		FirstURL string `json:"FirstURL"`
-		Text     string `json:"Text"`
-	} `json:"RelatedTopics"`
+	Heading          string            `json:"Heading"`
+	Abstract         string            `json:"Abstract"`
+	AbstractText     string            `json:"AbstractText"`
+	AbstractSource   string            `json:"AbstractSource"`
+	AbstractURL      string            `json:"AbstractURL"`
+	Answer           string            `json:"Answer"`
+	Definition       string            `json:"Definition"`
+	DefinitionSource string            `json:"DefinitionSource"`
+	DefinitionURL    string            `json:"DefinitionURL"`
+	Image            string            `json:"Image"`
+	Infobox          json.RawMessage   `json:"Infobox"`
+	RelatedTopics    []duckDuckGoTopic `json:"RelatedTopics"`
+}
+
+// duckDuckGoTopic 相关主题，分组主题的子项在 Topics 中
+type duckDuckGoTopic struct {
+	FirstURL string            `json:"FirstURL"`
+	Text     string            `json:"Text"`
+	Name     string            `json:"Name"`
+	Topics   []duckDuckGoTopic `json:"Topics"`
 }
 
-// SearchInstantAnswer 使用 Instant Answer API（备用）
+// duckDuckGoInfobox 信息框；没有信息框时接口返回空字符串
+type duckDuckGoInfobox struct {
+	Content []struct {
+		Label string      `json:"label"`
+		Value interface{} `json:"value"`
+	} `json:"content"`
+}
+
+// SearchInstantAnswer 调用 Instant Answer API
 func (e *DuckDuckGoEngine) SearchInstantAnswer(ctx context.Context, query string) (*DuckDuckGoInstantAnswer, error) {
-	apiURL := fmt.Sprintf("https://api.duckduckgo.com/?q=%s&format=json&no_html=1", url.QueryEscape(query))
+	apiURL := fmt.Sprintf("https://api.duckduckgo.com/?q=%s&format=json&no_html=1&skip_disambig=1", url.QueryEscape(query))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
@@ -191,3 +437,69 @@ func (e *DuckDuckGoEngine) SearchInstantAnswer(ctx context.Context, query string
 
 	return &answer, nil
 }
+
+// InstantAnswer 返回统一格式的即时答案
+func (e *DuckDuckGoEngine) InstantAnswer(ctx context.Context, query string) (*InstantAnswer, error) {
+	raw, err := e.SearchInstantAnswer(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("duckduckgo instant answer failed: %w", err)
+	}
+
+	answer := &InstantAnswer{
+		Query:      query,
+		Engine:     "duckduckgo",
+		Heading:    raw.Heading,
+		Answer:     cleanText(raw.Answer),
+		Abstract:   cleanText(raw.AbstractText),
+		Definition: cleanText(raw.Definition),
+		Source:     raw.AbstractSource,
+		SourceURL:  raw.AbstractURL,
+	}
+	if answer.Source == "" {
+		answer.Source = raw.DefinitionSource
+		answer.SourceURL = raw.DefinitionURL
+	}
+	if raw.Image != "" {
+		answer.Image = raw.Image
+		if strings.HasPrefix(answer.Image, "/") {
+			answer.Image = "https://duckduckgo.com" + answer.Image
+		}
+	}
+
+	var infobox duckDuckGoInfobox
+	if len(raw.Infobox) > 0 && json.Unmarshal(raw.Infobox, &infobox) == nil {
+		for _, item := range infobox.Content {
+			// 值可能是字符串、数字或对象，只保留可直接展示的
+			var value string
+			switch v := item.Value.(type) {
+			case string:
+				value = v
+			case float64:
+				value = fmt.Sprintf("%g", v)
+			}
+			if item.Label != "" && value != "" {
+				answer.Infobox = append(answer.Infobox, InfoboxEntry{Label: item.Label, Value: value})
+			}
+		}
+	}
+
+	// 分组主题展开为平铺列表，最多保留 10 个
+	var addTopics func(topics []duckDuckGoTopic)
+	addTopics = func(topics []duckDuckGoTopic) {
+		for _, t := range topics {
+			if len(answer.RelatedTopics) >= 10 {
+				return
+			}
+			if len(t.Topics) > 0 {
+				addTopics(t.Topics)
+				continue
+			}
+			if t.Text != "" {
+				answer.RelatedTopics = append(answer.RelatedTopics, RelatedTopic{Text: t.Text, URL: t.FirstURL})
+			}
+		}
+	}
+	addTopics(raw.RelatedTopics)
+
+	return answer, nil
+}
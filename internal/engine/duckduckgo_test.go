@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// duckDuckGoPost 记录的表单请求
+type duckDuckGoPost struct {
+	host string
+	form url.Values
+}
+
+// duckDuckGoWebClient 按表单中的 s 返回各页样本，记录每次 POST 的地址和表单
+func duckDuckGoWebClient(t *testing.T, pages map[string]string, posts *[]duckDuckGoPost) *http.Client {
+	return fixtureClient(func(req *http.Request) (int, string) {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		*posts = append(*posts, duckDuckGoPost{host: req.URL.Host, form: form})
+
+		if fixture, ok := pages[req.URL.Host+" "+form.Get("s")]; ok {
+			if fixture == "challenge" {
+				return http.StatusAccepted, `<form id="challenge-form"></form>`
+			}
+			return http.StatusOK, readFixture(t, fixture)
+		}
+		return http.StatusOK, "<html></html>"
+	})
+}
+
+func TestDuckDuckGoWebPagination(t *testing.T) {
+	var posts []duckDuckGoPost
+	e := &DuckDuckGoEngine{client: duckDuckGoWebClient(t, map[string]string{
+		"html.duckduckgo.com ":   "duckduckgo_html_page1.html",
+		"html.duckduckgo.com 10": "duckduckgo_html_page2.html",
+	}, &posts)}
+
+	results, err := e.Search(context.Background(), "golang", 10, SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 第二页用“下一页”表单的隐藏字段提交，第二页没有下一页表单时停止
+	if len(posts) != 2 {
+		t.Fatalf("posted %d pages, want 2", len(posts))
+	}
+	next := posts[1].form
+	if next.Get("q") != "golang" || next.Get("s") != "10" || next.Get("dc") != "11" || next.Get("vqd") != "4-123456789" {
+		t.Errorf("next page form = %v", next)
+	}
+	if _, ok := next["b"]; ok {
+		t.Errorf("next page form should not carry the submit button: %v", next)
+	}
+
+	// 广告的相对链接被跳过，跳转链接被还原
+	var urls []string
+	for _, r := range results {
+		urls = append(urls, r.URL)
+	}
+	want := []string{"https://go.dev/doc/", "https://gobyexample.com/", "https://go.dev/tour/"}
+	if !reflect.DeepEqual(urls, want) {
+		t.Fatalf("urls = %v, want %v", urls, want)
+	}
+	if r := results[0]; r.Title != "Documentation - The Go Programming Language" || r.Source != "go.dev/doc" || r.Description != "The Go programming language documentation." {
+		t.Errorf("results[0] = %+v", r)
+	}
+}
+
+func TestDuckDuckGoWebOffset(t *testing.T) {
+	var posts []duckDuckGoPost
+	e := &DuckDuckGoEngine{client: duckDuckGoWebClient(t, map[string]string{
+		"html.duckduckgo.com 30": "duckduckgo_html_page2.html",
+	}, &posts)}
+
+	if _, err := e.Search(context.Background(), "golang", 10, SearchOptions{Offset: 30}); err != nil {
+		t.Fatal(err)
+	}
+	if form := posts[0].form; form.Get("s") != "30" || form.Get("dc") != "31" {
+		t.Errorf("first page form = %v", form)
+	}
+}
+
+func TestDuckDuckGoLiteFallback(t *testing.T) {
+	var posts []duckDuckGoPost
+	e := &DuckDuckGoEngine{client: duckDuckGoWebClient(t, map[string]string{
+		"html.duckduckgo.com ": "challenge",
+		"lite.duckduckgo.com ": "duckduckgo_lite.html",
+	}, &posts)}
+
+	results, err := e.Search(context.Background(), "golang", 10, SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// html 返回人机验证后用同一份表单请求 lite，之后的页面继续使用 lite
+	hosts := []string{"html.duckduckgo.com", "lite.duckduckgo.com", "lite.duckduckgo.com"}
+	if len(posts) != len(hosts) {
+		t.Fatalf("posted %d times, want %d", len(posts), len(hosts))
+	}
+	for i, host := range hosts {
+		if posts[i].host != host {
+			t.Errorf("post %d went to %s, want %s", i, posts[i].host, host)
+		}
+	}
+	if posts[2].form.Get("s") != "10" {
+		t.Errorf("lite next page form = %v", posts[2].form)
+	}
+
+	// 广告行被跳过
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+	want := SearchResult{
+		Title:       "The Go Programming Language",
+		URL:         "https://go.dev/",
+		Description: "Go is an open source programming language.",
+		Source:      "go.dev",
+		Engine:      "duckduckgo",
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+}
+
+func TestDuckDuckGoChallengeOnBothEndpoints(t *testing.T) {
+	var posts []duckDuckGoPost
+	e := &DuckDuckGoEngine{client: duckDuckGoWebClient(t, map[string]string{
+		"html.duckduckgo.com ": "challenge",
+		"lite.duckduckgo.com ": "challenge",
+	}, &posts)}
+
+	if _, err := e.Search(context.Background(), "golang", 10, SearchOptions{}); !errors.Is(err, errDuckDuckGoChallenge) {
+		t.Errorf("Search() error = %v, want errDuckDuckGoChallenge", err)
+	}
+}
@@ -0,0 +1,254 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// vqdPattern 从 DuckDuckGo 页面中提取 vqd 令牌
+var vqdPattern = regexp.MustCompile(`vqd=["']?([0-9-]+)`)
+
+// duckDuckGoVerticalResponse news.js / i.js / v.js 接口的通用响应
+type duckDuckGoVerticalResponse struct {
+	Next    string                     `json:"next"`
+	Results []duckDuckGoVerticalResult `json:"results"`
+}
+
+// duckDuckGoVerticalResult 垂直搜索单条结果（各接口字段的并集）
+type duckDuckGoVerticalResult struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Excerpt     string `json:"excerpt"`
+	Source      string `json:"source"`
+	Date        int64  `json:"date"`
+	Image       string `json:"image"`
+	Thumbnail   string `json:"thumbnail"`
+	Content     string `json:"content"`
+	Description string `json:"description"`
+	Duration    string `json:"duration"`
+	Publisher   string `json:"publisher"`
+	Published   string `json:"published"`
+	Images      struct {
+		Medium string `json:"medium"`
+		Small  string `json:"small"`
+	} `json:"images"`
+}
+
+// fetchVQD 获取查询对应的 vqd 令牌，垂直搜索接口需要携带
+func (e *DuckDuckGoEngine) fetchVQD(ctx context.Context, query string) (string, error) {
+	searchURL := fmt.Sprintf("https://duckduckgo.com/?q=%s", url.QueryEscape(query))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create request failed: %w", err)
+	}
+
+	e.setHeaders(req)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read body failed: %w", err)
+	}
+
+	m := vqdPattern.FindSubmatch(body)
+	if m == nil {
+		return "", fmt.Errorf("vqd token not found")
+	}
+
+	return string(m[1]), nil
+}
+
+// searchVertical 执行新闻、图片或视频搜索
+func (e *DuckDuckGoEngine) searchVertical(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	searchType := opts.Type
+
+	vqd, err := e.fetchVQD(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("duckduckgo %s search failed: %w", searchType, err)
+	}
+
+	var endpoint string
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("vqd", vqd)
+	params.Set("o", "json")
+
+	kl := duckDuckGoLocale(opts)
+	if kl == "" {
+		kl = "us-en"
+	}
+	params.Set("l", kl)
+	if opts.Offset > 0 {
+		params.Set("s", fmt.Sprintf("%d", opts.Offset))
+	}
+	df := duckDuckGoTimeFilter(opts)
+
+	switch searchType {
+	case SearchTypeNews:
+		endpoint = "news.js"
+		params.Set("noamp", "1")
+		params.Set("p", "-1")
+		if df != "" {
+			params.Set("df", df)
+		}
+	case SearchTypeImages:
+		endpoint = "i.js"
+		params.Set("f", duckDuckGoImageFilter(opts))
+		params.Set("p", "1")
+	case SearchTypeVideos:
+		endpoint = "v.js"
+		params.Set("f", ",,,")
+		params.Set("p", "-1")
+		if df != "" {
+			params.Set("df", df)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported search type: %s", searchType)
+	}
+
+	nextURL := fmt.Sprintf("https://duckduckgo.com/%s?%s", endpoint, params.Encode())
+	now := time.Now()
+
+	var allResults []SearchResult
+	for page := 0; nextURL != "" && len(allResults) < limit && page < 5; page++ {
+		data, err := e.fetchVerticalPage(ctx, nextURL)
+		if err != nil {
+			if len(allResults) > 0 {
+				break
+			}
+			return nil, err
+		}
+
+		if len(data.Results) == 0 {
+			break
+		}
+
+		for _, item := range data.Results {
+			if result := e.convertVerticalResult(item, searchType, now); result != nil {
+				allResults = append(allResults, *result)
+			}
+		}
+
+		// next 为相对路径，需要重新带上 vqd
+		nextURL = ""
+		if data.Next != "" {
+			nextURL = "https://duckduckgo.com/" + strings.TrimPrefix(data.Next, "/") + "&vqd=" + url.QueryEscape(vqd)
+		}
+	}
+
+	// 自定义时间范围在部分接口上不生效，按发布时间兜底过滤
+	if opts.Freshness == FreshnessCustom {
+		allResults = filterByPublishedTime(allResults, opts, now)
+	}
+
+	if len(allResults) > limit {
+		allResults = allResults[:limit]
+	}
+
+	log.Printf("🔍 DuckDuckGo %s: found %d results for query '%s'", searchType, len(allResults), query)
+	return allResults, nil
+}
+
+// duckDuckGoImageFilter 生成图片搜索的 f 参数（time,size,color,type,layout,license），时间仅支持 Day/Week/Month/Year
+func duckDuckGoImageFilter(opts SearchOptions) string {
+	switch opts.Freshness {
+	case FreshnessDay:
+		return "time:Day,,,,,"
+	case FreshnessWeek:
+		return "time:Week,,,,,"
+	case FreshnessMonth:
+		return "time:Month,,,,,"
+	case FreshnessYear:
+		return "time:Year,,,,,"
+	}
+	return ",,,,,"
+}
+
+// fetchVerticalPage 请求单页垂直搜索 JSON
+func (e *DuckDuckGoEngine) fetchVerticalPage(ctx context.Context, pageURL string) (*duckDuckGoVerticalResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+
+	e.setHeaders(req)
+	req.Header.Set("Accept", "application/json, text/javascript, */*; q=0.01")
+	req.Header.Set("Referer", "https://duckduckgo.com/")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	var data duckDuckGoVerticalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("parse JSON failed: %w", err)
+	}
+
+	return &data, nil
+}
+
+// convertVerticalResult 将垂直搜索结果转换为统一结构
+func (e *DuckDuckGoEngine) convertVerticalResult(item duckDuckGoVerticalResult, searchType string, now time.Time) *SearchResult {
+	result := SearchResult{
+		Title:  cleanText(item.Title),
+		Engine: "duckduckgo",
+	}
+
+	switch searchType {
+	case SearchTypeNews:
+		result.URL = item.URL
+		result.Description = cleanText(item.Excerpt)
+		result.Thumbnail = item.Image
+		result.Publisher = item.Source
+		if item.Date > 0 {
+			result.PublishedAt = time.Unix(item.Date, 0).UTC().Format(time.RFC3339)
+		}
+	case SearchTypeImages:
+		result.URL = item.URL
+		result.Thumbnail = item.Thumbnail
+		result.MediaURL = item.Image
+		result.Publisher = item.Source
+	case SearchTypeVideos:
+		result.URL = item.Content
+		result.Description = truncateText(cleanText(item.Description), 500)
+		result.Thumbnail = item.Images.Medium
+		if result.Thumbnail == "" {
+			result.Thumbnail = item.Images.Small
+		}
+		result.MediaURL = item.Content
+		result.Duration = item.Duration
+		result.Publisher = item.Publisher
+		result.PublishedAt = parseDisplayTime(item.Published, now)
+	}
+
+	if result.URL == "" || result.Title == "" {
+		return nil
+	}
+
+	if parsedURL, err := url.Parse(result.URL); err == nil {
+		result.Source = parsedURL.Host
+	}
+
+	return &result
+}
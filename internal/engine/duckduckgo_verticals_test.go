@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// duckDuckGoVerticalClient 首页返回 vqd 令牌，接口返回样本，记录接口请求
+func duckDuckGoVerticalClient(t *testing.T, endpoint, fixture string, requests *[]*http.Request) *http.Client {
+	return fixtureClient(func(req *http.Request) (int, string) {
+		switch req.URL.Path {
+		case "/":
+			return http.StatusOK, `<script>vqd="4-123456789"</script>`
+		case endpoint:
+			*requests = append(*requests, req)
+			if req.URL.Query().Get("s") == "" {
+				return http.StatusOK, readFixture(t, fixture)
+			}
+		}
+		return http.StatusOK, `{"results":[]}`
+	})
+}
+
+func TestDuckDuckGoNews(t *testing.T) {
+	var requests []*http.Request
+	e := &DuckDuckGoEngine{client: duckDuckGoVerticalClient(t, "/news.js", "duckduckgo_news.json", &requests)}
+
+	results, err := e.Search(context.Background(), "golang", 10, SearchOptions{Type: SearchTypeNews})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 按 next 继续请求下一页，并带上 vqd
+	if len(requests) != 2 {
+		t.Fatalf("requested %d pages, want 2", len(requests))
+	}
+	if vqd := requests[0].URL.Query().Get("vqd"); vqd != "4-123456789" {
+		t.Errorf("first page vqd = %q", vqd)
+	}
+	if q := requests[1].URL.Query(); q.Get("s") != "2" || q.Get("vqd") != "4-123456789" {
+		t.Errorf("next page query = %v", q)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1: %+v", len(results), results)
+	}
+	want := SearchResult{
+		Title:       "Go 1.22 released",
+		URL:         "https://www.theverge.com/go-release",
+		Description: "Range over integers",
+		Source:      "www.theverge.com",
+		Engine:      "duckduckgo",
+		Thumbnail:   "https://external-content.duckduckgo.com/iu/?u=1",
+		Publisher:   "The Verge",
+		PublishedAt: "2024-02-07T00:00:00Z",
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+}
+
+func TestDuckDuckGoImages(t *testing.T) {
+	var requests []*http.Request
+	e := &DuckDuckGoEngine{client: duckDuckGoVerticalClient(t, "/i.js", "duckduckgo_images.json", &requests)}
+
+	results, err := e.Search(context.Background(), "gopher", 10, SearchOptions{Type: SearchTypeImages})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	r := results[0]
+	if r.URL != "https://go.dev/blog/gopher" || r.MediaURL != "https://go.dev/images/gopher.png" || r.Thumbnail != "https://tse1.mm.bing.net/th?id=OIP.1" {
+		t.Errorf("results[0] = %+v", r)
+	}
+}
+
+func TestDuckDuckGoVideos(t *testing.T) {
+	var requests []*http.Request
+	e := &DuckDuckGoEngine{client: duckDuckGoVerticalClient(t, "/v.js", "duckduckgo_videos.json", &requests)}
+
+	results, err := e.Search(context.Background(), "go concurrency", 10, SearchOptions{Type: SearchTypeVideos})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	r := results[0]
+	if r.URL != "https://www.youtube.com/watch?v=f6kdp27TYZs" || r.MediaURL != r.URL || r.Duration != "51:26" {
+		t.Errorf("results[0] = %+v", r)
+	}
+	// 没有中等尺寸缩略图时使用小图
+	if r.Thumbnail != "https://tse2.mm.bing.net/th?id=OVP.1" || r.Publisher != "YouTube" || r.PublishedAt != "2012-07-02T00:00:00Z" {
+		t.Errorf("results[0] metadata = %+v", r)
+	}
+}
+
+func TestDuckDuckGoVerticalWithoutVQD(t *testing.T) {
+	e := &DuckDuckGoEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		return http.StatusOK, "<html></html>"
+	})}
+	if _, err := e.Search(context.Background(), "golang", 10, SearchOptions{Type: SearchTypeNews}); err == nil {
+		t.Error("Search() without a vqd token returned no error")
+	}
+}
@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 时间范围
+const (
+	FreshnessDay    = "day"
+	FreshnessWeek   = "week"
+	FreshnessMonth  = "month"
+	FreshnessYear   = "year"
+	FreshnessCustom = "custom"
+)
+
+// FreshnessValues 所有支持的时间范围
+var FreshnessValues = []string{FreshnessDay, FreshnessWeek, FreshnessMonth, FreshnessYear, FreshnessCustom}
+
+// 过滤条件名称，引擎通过 Capabilities 声明自己能处理哪些
+const (
+	FilterFreshness = "freshness"  // day/week/month/year
+	FilterDateRange = "date_range" // custom + date_from/date_to
+	FilterRegion    = "region"
+	FilterLanguage  = "language"
+	FilterSort      = "sort" // sort=date
+)
+
+// dateLayout 自定义时间范围使用的日期格式
+const dateLayout = "2006-01-02"
+
+// Validate 校验搜索参数
+func (o SearchOptions) Validate() error {
+	if o.Type != "" && !containsString(SearchTypes, o.Type) {
+		return fmt.Errorf("invalid type: %s (expected one of %v)", o.Type, SearchTypes)
+	}
+
+	if o.Offset < 0 {
+		return fmt.Errorf("invalid offset: %d (must be >= 0)", o.Offset)
+	}
+
+	if o.Sort != "" && o.Sort != SortRelevance && o.Sort != SortDate {
+		return fmt.Errorf("invalid sort: %s (expected relevance or date)", o.Sort)
+	}
+
+	if o.Freshness != "" && !containsString(FreshnessValues, o.Freshness) {
+		return fmt.Errorf("invalid freshness: %s (expected one of %v)", o.Freshness, FreshnessValues)
+	}
+
+	if o.Freshness == FreshnessCustom && o.DateFrom == "" && o.DateTo == "" {
+		return fmt.Errorf("freshness=custom requires date_from and/or date_to (YYYY-MM-DD)")
+	}
+
+	var from, to time.Time
+	var err error
+	if o.DateFrom != "" {
+		if from, err = time.Parse(dateLayout, o.DateFrom); err != nil {
+			return fmt.Errorf("invalid date_from: %s (expected YYYY-MM-DD)", o.DateFrom)
+		}
+	}
+	if o.DateTo != "" {
+		if to, err = time.Parse(dateLayout, o.DateTo); err != nil {
+			return fmt.Errorf("invalid date_to: %s (expected YYYY-MM-DD)", o.DateTo)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return fmt.Errorf("date_to %s is before date_from %s", o.DateTo, o.DateFrom)
+	}
+
+	return nil
+}
+
+// normalize 规范化过滤参数（小写、指定日期时默认 custom）
+func (o *SearchOptions) normalize() {
+	o.Region = strings.ToLower(strings.TrimSpace(o.Region))
+	o.Language = strings.ToLower(strings.TrimSpace(o.Language))
+	if o.Freshness == "" && (o.DateFrom != "" || o.DateTo != "") {
+		o.Freshness = FreshnessCustom
+	}
+}
+
+// requestedFilters 返回请求中使用的过滤条件
+func (o SearchOptions) requestedFilters() []string {
+	var filters []string
+	switch o.Freshness {
+	case "":
+	case FreshnessCustom:
+		filters = append(filters, FilterDateRange)
+	default:
+		filters = append(filters, FilterFreshness)
+	}
+	if o.Region != "" {
+		filters = append(filters, FilterRegion)
+	}
+	if o.Language != "" {
+		filters = append(filters, FilterLanguage)
+	}
+	if o.Sort == SortDate {
+		filters = append(filters, FilterSort)
+	}
+	return filters
+}
+
+// unsupportedFilters 返回引擎无法处理的过滤条件
+func unsupportedFilters(engine SearchEngine, opts SearchOptions) []string {
+	supported := engine.Capabilities().Filters
+	var missing []string
+	for _, f := range opts.requestedFilters() {
+		if !containsString(supported, f) {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// timeRange 将时间范围转换为绝对时间区间，未设置时返回 ok=false
+func (o SearchOptions) timeRange(now time.Time) (from, to time.Time, ok bool) {
+	to = now
+	switch o.Freshness {
+	case FreshnessDay:
+		from = now.AddDate(0, 0, -1)
+	case FreshnessWeek:
+		from = now.AddDate(0, 0, -7)
+	case FreshnessMonth:
+		from = now.AddDate(0, -1, 0)
+	case FreshnessYear:
+		from = now.AddDate(-1, 0, 0)
+	case FreshnessCustom:
+		// 未指定起点时从很早开始，未指定终点时到当前时间
+		from = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+		if t, err := time.Parse(dateLayout, o.DateFrom); err == nil {
+			from = t
+		}
+		if t, err := time.Parse(dateLayout, o.DateTo); err == nil {
+			// 包含结束当天
+			to = t.Add(24*time.Hour - time.Second)
+		}
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// acceptLanguage 根据语言参数生成 Accept-Language 头，未指定时使用默认值
+func acceptLanguage(language, fallback string) string {
+	if language == "" {
+		return fallback
+	}
+	return fmt.Sprintf("%s,%s;q=0.9,en;q=0.8", language, language)
+}
+
+func containsString(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
+
+// filterByPublishedTime 按发布时间过滤结果，用于引擎无法原生处理时间范围的情况；无发布时间的结果保留
+func filterByPublishedTime(results []SearchResult, opts SearchOptions, now time.Time) []SearchResult {
+	from, to, ok := opts.timeRange(now)
+	if !ok {
+		return results
+	}
+
+	filtered := results[:0]
+	for _, r := range results {
+		if r.PublishedAt != "" {
+			if t, err := time.Parse(time.RFC3339, r.PublishedAt); err == nil && (t.Before(from) || t.After(to)) {
+				continue
+			}
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
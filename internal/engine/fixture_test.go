@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixtureTransport 用固定的响应代替网络请求，返回状态码和响应内容
+type fixtureTransport func(req *http.Request) (int, string)
+
+func (f fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	status, body := f(req)
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// fixtureClient 创建使用固定响应的 HTTP 客户端
+func fixtureClient(f func(req *http.Request) (int, string)) *http.Client {
+	return &http.Client{Transport: fixtureTransport(f)}
+}
+
+// readFixture 读取 testdata 下的响应样本
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// queryParams 解析请求的查询参数
+func queryParams(t *testing.T, rawQuery string) url.Values {
+	t.Helper()
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return params
+}
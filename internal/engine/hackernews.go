@@ -0,0 +1,175 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HackerNewsEngine Hacker News 讨论搜索引擎实现（基于 Algolia API）
+type HackerNewsEngine struct {
+	client   *http.Client
+	proxyURL string
+}
+
+// NewHackerNewsEngine 创建 Hacker News 搜索引擎实例
+func NewHackerNewsEngine(proxyURL string, limiter *RateLimiter) *HackerNewsEngine {
+	transport := &http.Transport{}
+	if proxyURL != "" {
+		if proxy, err := url.Parse(proxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+
+	client := &http.Client{
+		Transport: newRateLimitedTransport(limiter, transport),
+	}
+
+	return &HackerNewsEngine{
+		client:   client,
+		proxyURL: proxyURL,
+	}
+}
+
+// Name 返回引擎名称
+func (e *HackerNewsEngine) Name() string {
+	return "hackernews"
+}
+
+// Capabilities 返回引擎能力描述
+func (e *HackerNewsEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryDiscussion,
+		Description: "Hacker News stories via Algolia",
+		Verticals:   webOnly,
+		Filters:     []string{FilterFreshness, FilterDateRange, FilterSort},
+		MaxResults:  100,
+		Pagination:  true,
+		RegionFocus: RegionGlobal,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符（Algolia 默认不启用高级语法）
+func (e *HackerNewsEngine) QueryDialect() QueryDialect {
+	return QueryDialect{}
+}
+
+// hackerNewsResponse Algolia 搜索接口响应
+type hackerNewsResponse struct {
+	Hits []hackerNewsHit `json:"hits"`
+}
+
+// hackerNewsHit 单条帖子
+type hackerNewsHit struct {
+	ObjectID    string `json:"objectID"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Points      int    `json:"points"`
+	NumComments int    `json:"num_comments"`
+	CreatedAt   string `json:"created_at"`
+	StoryText   string `json:"story_text"`
+}
+
+// Search 执行 Hacker News 搜索
+func (e *HackerNewsEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	// search 按相关度排序，search_by_date 按时间倒序
+	endpoint := "search"
+	if opts.Sort == SortDate {
+		endpoint = "search_by_date"
+	}
+
+	hitsPerPage := limit
+	if hitsPerPage > 100 {
+		hitsPerPage = 100
+	}
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("tags", "story")
+	params.Set("hitsPerPage", fmt.Sprintf("%d", hitsPerPage))
+	if opts.Offset > 0 {
+		// Algolia 支持 offset/length 代替 page/hitsPerPage
+		params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+		params.Set("length", fmt.Sprintf("%d", hitsPerPage))
+	}
+	if from, to, ok := opts.timeRange(time.Now()); ok {
+		params.Set("numericFilters", fmt.Sprintf("created_at_i>=%d,created_at_i<=%d", from.Unix(), to.Unix()))
+	}
+
+	searchURL := fmt.Sprintf("https://hn.algolia.com/api/v1/%s?%s", endpoint, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	req.Header.Set("User-Agent", "go-web-search-mcp/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	var data hackerNewsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("parse JSON failed: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(data.Hits))
+	for _, hit := range data.Hits {
+		if result := e.parseHit(hit); result != nil {
+			results = append(results, *result)
+		}
+	}
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	log.Printf("🔍 Hacker News: found %d results for query '%s'", len(results), query)
+	return results, nil
+}
+
+// parseHit 解析单条帖子
+func (e *HackerNewsEngine) parseHit(hit hackerNewsHit) *SearchResult {
+	title := cleanText(hit.Title)
+	if title == "" || hit.ObjectID == "" {
+		return nil
+	}
+
+	discussionURL := "https://news.ycombinator.com/item?id=" + hit.ObjectID
+
+	// Ask HN / Show HN 等没有外链的帖子，直接使用讨论页
+	href := hit.URL
+	if href == "" {
+		href = discussionURL
+	}
+
+	source := "news.ycombinator.com"
+	if parsedURL, err := url.Parse(href); err == nil && parsedURL.Host != "" {
+		source = parsedURL.Host
+	}
+
+	return &SearchResult{
+		Title:         title,
+		URL:           href,
+		Description:   truncateText(cleanText(hit.StoryText), 500),
+		Source:        source,
+		Engine:        "hackernews",
+		Points:        hit.Points,
+		CommentCount:  hit.NumComments,
+		DiscussionURL: discussionURL,
+		PublishedAt:   hit.CreatedAt,
+	}
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHackerNewsSearch(t *testing.T) {
+	var path, query string
+	e := &HackerNewsEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		path, query = req.URL.Path, req.URL.RawQuery
+		return http.StatusOK, readFixture(t, "hackernews.json")
+	})}
+
+	results, err := e.Search(context.Background(), "golang", 10, SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	want := SearchResult{
+		Title:         "Go 1.22 Release Notes",
+		URL:           "https://go.dev/doc/go1.22",
+		Source:        "go.dev",
+		Engine:        "hackernews",
+		Points:        412,
+		CommentCount:  183,
+		DiscussionURL: "https://news.ycombinator.com/item?id=38860112",
+		PublishedAt:   "2024-02-06T18:01:42Z",
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+
+	// Ask HN 没有外链，使用讨论页
+	ask := results[1]
+	if ask.URL != "https://news.ycombinator.com/item?id=38861000" || ask.Source != "news.ycombinator.com" {
+		t.Errorf("Ask HN result = %+v", ask)
+	}
+	if ask.Title != "Ask HN: How do you structure Go projects?" || ask.Description != "Looking for advice on layouts." {
+		t.Errorf("Ask HN text not cleaned: %+v", ask)
+	}
+
+	// 默认按相关度排序，只搜索帖子
+	params := queryParams(t, query)
+	if path != "/api/v1/search" || params.Get("tags") != "story" || params.Get("hitsPerPage") != "10" {
+		t.Errorf("request = %s?%s", path, query)
+	}
+}
+
+func TestHackerNewsSortAndOffset(t *testing.T) {
+	var path, query string
+	e := &HackerNewsEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		path, query = req.URL.Path, req.URL.RawQuery
+		return http.StatusOK, `{"hits": []}`
+	})}
+
+	if _, err := e.Search(context.Background(), "golang", 20, SearchOptions{Sort: SortDate, Offset: 40}); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/api/v1/search_by_date" {
+		t.Errorf("path = %s, want /api/v1/search_by_date", path)
+	}
+	if params := queryParams(t, query); params.Get("offset") != "40" || params.Get("length") != "20" {
+		t.Errorf("request params = %v", params)
+	}
+}
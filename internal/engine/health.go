@@ -0,0 +1,274 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen 引擎处于熔断状态，本次请求被跳过
+var ErrCircuitOpen = errors.New("circuit open")
+
+// 熔断器状态
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// EngineHealth 引擎健康状况
+type EngineHealth struct {
+	Engine              string `json:"engine"`
+	State               string `json:"state"`
+	Successes           int64  `json:"successes"`
+	Failures            int64  `json:"failures"`
+	Captchas            int64  `json:"captchas"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+	LastSuccessAt       string `json:"last_success_at,omitempty"`
+	LastFailureAt       string `json:"last_failure_at,omitempty"`
+	OpenUntil           string `json:"open_until,omitempty"`
+	// P95LatencyMS 最近成功搜索的 p95 延迟
+	P95LatencyMS int64 `json:"p95_latency_ms,omitempty"`
+}
+
+// latencyWindow 计算 p95 延迟时保留的最近成功次数
+const latencyWindow = 100
+
+// circuit 单个引擎的熔断器
+type circuit struct {
+	state       string
+	successes   int64
+	failures    int64
+	captchas    int64
+	consecutive int
+	trips       int
+	lastError   string
+	lastSuccess time.Time
+	lastFailure time.Time
+	openUntil   time.Time
+	probing     bool
+	// latencies 最近成功搜索的延迟（环形缓冲）
+	latencies []time.Duration
+	next      int
+}
+
+// HealthTracker 记录各引擎的成功与失败，连续失败或遇到验证码时熔断，冷却时间按指数增长
+type HealthTracker struct {
+	enabled     bool
+	threshold   int
+	cooldown    time.Duration
+	maxCooldown time.Duration
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// NewHealthTracker 创建引擎健康跟踪器
+func NewHealthTracker(enabled bool, threshold int, cooldown, maxCooldown time.Duration) *HealthTracker {
+	return &HealthTracker{
+		enabled:     enabled,
+		threshold:   threshold,
+		cooldown:    cooldown,
+		maxCooldown: maxCooldown,
+		circuits:    make(map[string]*circuit),
+	}
+}
+
+// get 获取引擎的熔断器，不存在时创建（调用方需持有锁）
+func (h *HealthTracker) get(name string) *circuit {
+	c, ok := h.circuits[name]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		h.circuits[name] = c
+	}
+	return c
+}
+
+// Allow 判断是否可以向引擎发送请求；冷却结束后进入半开状态，只放行一个探测请求
+func (h *HealthTracker) Allow(name string) (bool, time.Time) {
+	if !h.enabled {
+		return true, time.Time{}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c := h.get(name)
+	switch c.state {
+	case CircuitOpen:
+		if time.Now().Before(c.openUntil) {
+			return false, c.openUntil
+		}
+		c.state = CircuitHalfOpen
+		c.probing = true
+		return true, time.Time{}
+	case CircuitHalfOpen:
+		if c.probing {
+			return false, c.openUntil
+		}
+		c.probing = true
+		return true, time.Time{}
+	}
+	return true, time.Time{}
+}
+
+// RecordSuccess 记录成功，关闭熔断器并重置冷却时间
+func (h *HealthTracker) RecordSuccess(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c := h.get(name)
+	c.successes++
+	c.consecutive = 0
+	c.trips = 0
+	c.state = CircuitClosed
+	c.probing = false
+	c.lastSuccess = time.Now()
+}
+
+// RecordLatency 记录一次成功搜索的延迟
+func (h *HealthTracker) RecordLatency(name string, latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c := h.get(name)
+	if len(c.latencies) < latencyWindow {
+		c.latencies = append(c.latencies, latency)
+		return
+	}
+	c.latencies[c.next] = latency
+	c.next = (c.next + 1) % latencyWindow
+}
+
+// LatencyP95 返回引擎最近成功搜索的 p95 延迟，样本少于 minSamples 时返回 false
+func (h *HealthTracker) LatencyP95(name string, minSamples int) (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c := h.get(name)
+	if len(c.latencies) == 0 || len(c.latencies) < minSamples {
+		return 0, false
+	}
+	return c.p95(), true
+}
+
+// p95 计算延迟样本的 p95（调用方需持有锁）
+func (c *circuit) p95() time.Duration {
+	sorted := append([]time.Duration(nil), c.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[(len(sorted)*95+99)/100-1]
+}
+
+// RecordFailure 记录失败；遇到验证码、连续失败达到阈值或半开探测失败时熔断
+func (h *HealthTracker) RecordFailure(name string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c := h.get(name)
+	captcha := isCaptchaError(err)
+	c.failures++
+	c.consecutive++
+	if captcha {
+		c.captchas++
+	}
+	c.lastError = err.Error()
+	c.lastFailure = time.Now()
+
+	if !h.enabled {
+		return
+	}
+	if captcha || c.state == CircuitHalfOpen || c.consecutive >= h.threshold {
+		h.trip(c)
+	}
+}
+
+// Release 放弃半开状态的探测请求（例如调用方取消），让下一个请求继续探测
+func (h *HealthTracker) Release(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if c, ok := h.circuits[name]; ok {
+		c.probing = false
+	}
+}
+
+// trip 打开熔断器，冷却时间为 cooldown * 2^trips，不超过上限
+func (h *HealthTracker) trip(c *circuit) {
+	cooldown := h.cooldown
+	for i := 0; i < c.trips && cooldown < h.maxCooldown; i++ {
+		cooldown *= 2
+	}
+	if cooldown > h.maxCooldown {
+		cooldown = h.maxCooldown
+	}
+
+	c.trips++
+	c.state = CircuitOpen
+	c.probing = false
+	c.openUntil = time.Now().Add(cooldown)
+}
+
+// Snapshot 返回指定引擎的健康状况，按引擎名排序
+func (h *HealthTracker) Snapshot(names []string) []EngineHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	health := make([]EngineHealth, 0, len(names))
+	for _, name := range names {
+		c := h.get(name)
+		state := c.state
+		// 冷却已结束但还没有请求触发探测时，视为半开
+		if state == CircuitOpen && !now.Before(c.openUntil) {
+			state = CircuitHalfOpen
+		}
+
+		entry := EngineHealth{
+			Engine:              name,
+			State:               state,
+			Successes:           c.successes,
+			Failures:            c.failures,
+			Captchas:            c.captchas,
+			ConsecutiveFailures: c.consecutive,
+			LastError:           c.lastError,
+			LastSuccessAt:       formatTime(c.lastSuccess),
+			LastFailureAt:       formatTime(c.lastFailure),
+		}
+		if state == CircuitOpen {
+			entry.OpenUntil = formatTime(c.openUntil)
+		}
+		if len(c.latencies) > 0 {
+			entry.P95LatencyMS = c.p95().Milliseconds()
+		}
+		health = append(health, entry)
+	}
+
+	sort.Slice(health, func(i, j int) bool {
+		return health[i].Engine < health[j].Engine
+	})
+	return health
+}
+
+// isCaptchaError 判断错误是否由验证码、反爬或限流页面引起
+func isCaptchaError(err error) bool {
+	if errors.Is(err, errDuckDuckGoChallenge) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range []string{"captcha", "anti-spider", "rate limited", "challenge"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
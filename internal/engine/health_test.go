@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// expire 让熔断器的冷却立即结束
+func expire(h *HealthTracker, name string) {
+	h.mu.Lock()
+	h.circuits[name].openUntil = time.Now().Add(-time.Millisecond)
+	h.mu.Unlock()
+}
+
+// cooldownOf 返回熔断器剩余的冷却时间，按分钟取整
+func cooldownOf(h *HealthTracker, name string) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Until(h.circuits[name].openUntil).Round(time.Minute)
+}
+
+func TestHealthTrackerTripsAfterThreshold(t *testing.T) {
+	h := NewHealthTracker(true, 3, time.Hour, 4*time.Hour)
+	failure := errors.New("connection reset")
+
+	for i := 0; i < 2; i++ {
+		h.RecordFailure("bing", failure)
+		if ok, _ := h.Allow("bing"); !ok {
+			t.Fatalf("circuit opened after %d failures, threshold is 3", i+1)
+		}
+	}
+	// 成功后连续失败计数清零
+	h.RecordSuccess("bing")
+	h.RecordFailure("bing", failure)
+	h.RecordFailure("bing", failure)
+	if ok, _ := h.Allow("bing"); !ok {
+		t.Fatal("circuit opened although a success reset the failure count")
+	}
+
+	h.RecordFailure("bing", failure)
+	ok, until := h.Allow("bing")
+	if ok || until.IsZero() {
+		t.Fatalf("Allow() = %v, %v; want circuit open", ok, until)
+	}
+	if got := h.Snapshot([]string{"bing"})[0]; got.State != CircuitOpen || got.ConsecutiveFailures != 3 || got.Failures != 5 {
+		t.Errorf("Snapshot() = %+v", got)
+	}
+}
+
+func TestHealthTrackerCaptchaTripsImmediately(t *testing.T) {
+	h := NewHealthTracker(true, 5, time.Hour, 4*time.Hour)
+	h.RecordFailure("baidu", fmt.Errorf("baidu: %w", errors.New("captcha page")))
+
+	if ok, _ := h.Allow("baidu"); ok {
+		t.Fatal("captcha should open the circuit on the first failure")
+	}
+	if got := h.Snapshot([]string{"baidu"})[0]; got.Captchas != 1 {
+		t.Errorf("Captchas = %d, want 1", got.Captchas)
+	}
+}
+
+func TestHealthTrackerHalfOpen(t *testing.T) {
+	h := NewHealthTracker(true, 1, time.Hour, 4*time.Hour)
+	h.RecordFailure("bing", errors.New("timeout"))
+	expire(h, "bing")
+
+	if got := h.Snapshot([]string{"bing"})[0].State; got != CircuitHalfOpen {
+		t.Errorf("state after cooldown = %s, want %s", got, CircuitHalfOpen)
+	}
+
+	// 半开状态只放行一个探测请求
+	if ok, _ := h.Allow("bing"); !ok {
+		t.Fatal("probe request was not allowed")
+	}
+	if ok, _ := h.Allow("bing"); ok {
+		t.Fatal("second request allowed while probing")
+	}
+
+	// 探测被放弃后下一个请求可以继续探测
+	h.Release("bing")
+	if ok, _ := h.Allow("bing"); !ok {
+		t.Fatal("probe not allowed after Release")
+	}
+
+	h.RecordSuccess("bing")
+	for i := 0; i < 3; i++ {
+		if ok, _ := h.Allow("bing"); !ok {
+			t.Fatal("circuit not closed after successful probe")
+		}
+	}
+}
+
+func TestHealthTrackerCooldownBackoff(t *testing.T) {
+	h := NewHealthTracker(true, 1, time.Hour, 3*time.Hour)
+	failure := errors.New("timeout")
+
+	h.RecordFailure("bing", failure)
+	want := []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 3 * time.Hour}
+	for i, w := range want {
+		if got := cooldownOf(h, "bing"); got != w {
+			t.Errorf("trip %d cooldown = %v, want %v", i+1, got, w)
+		}
+		// 半开探测失败立即重新熔断，冷却时间翻倍
+		expire(h, "bing")
+		h.Allow("bing")
+		h.RecordFailure("bing", failure)
+	}
+
+	// 成功后冷却时间重置
+	expire(h, "bing")
+	h.Allow("bing")
+	h.RecordSuccess("bing")
+	h.RecordFailure("bing", failure)
+	if got := cooldownOf(h, "bing"); got != time.Hour {
+		t.Errorf("cooldown after success = %v, want %v", got, time.Hour)
+	}
+}
+
+func TestHealthTrackerDisabled(t *testing.T) {
+	h := NewHealthTracker(false, 1, time.Hour, time.Hour)
+	h.RecordFailure("bing", errors.New("captcha"))
+	if ok, _ := h.Allow("bing"); !ok {
+		t.Error("disabled tracker should always allow")
+	}
+	if got := h.Snapshot([]string{"bing"})[0]; got.Failures != 1 || got.State != CircuitClosed {
+		t.Errorf("Snapshot() = %+v", got)
+	}
+}
+
+func TestHealthTrackerLatencyP95(t *testing.T) {
+	h := NewHealthTracker(true, 3, time.Minute, time.Hour)
+	if _, ok := h.LatencyP95("bing", 1); ok {
+		t.Error("LatencyP95 without samples should report false")
+	}
+
+	for i := 1; i <= 20; i++ {
+		h.RecordLatency("bing", time.Duration(i)*time.Millisecond)
+	}
+	if _, ok := h.LatencyP95("bing", 50); ok {
+		t.Error("LatencyP95 below minSamples should report false")
+	}
+	if got, ok := h.LatencyP95("bing", 10); !ok || got != 19*time.Millisecond {
+		t.Errorf("LatencyP95() = %v, %v; want 19ms", got, ok)
+	}
+
+	// 环形缓冲只保留最近 latencyWindow 个样本
+	for i := 0; i < latencyWindow; i++ {
+		h.RecordLatency("bing", time.Second)
+	}
+	if got, _ := h.LatencyP95("bing", 1); got != time.Second {
+		t.Errorf("LatencyP95() after window = %v, want 1s", got)
+	}
+}
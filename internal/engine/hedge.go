@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// engineAttempt 一次引擎搜索的结果
+type engineAttempt struct {
+	engine  SearchEngine
+	res     engineSearch
+	err     error
+	latency time.Duration
+	// hedged 主引擎超过 p95 延迟后作为对冲请求启动
+	hedged bool
+}
+
+// succeeded 搜索成功且有结果
+func (a engineAttempt) succeeded() bool {
+	return a.err == nil && len(a.res.results) > 0
+}
+
+// attempt 使用单个引擎搜索并记录耗时
+func (m *Manager) attempt(ctx context.Context, engine SearchEngine, parsed *ParsedQuery, req SearchRequest, limit int) engineAttempt {
+	start := time.Now()
+	res, err := m.searchEngine(ctx, engine, parsed, req, limit)
+	return engineAttempt{engine: engine, res: res, err: err, latency: time.Since(start)}
+}
+
+// searchHedged 搜索主引擎；启用对冲且主引擎超过其 p95 延迟仍未返回时同时启动备用引擎，
+// 先返回结果的一方胜出，另一方被取消。返回按完成顺序排列的结果，未对冲时只有主引擎一项
+func (m *Manager) searchHedged(ctx context.Context, primary, hedge SearchEngine, parsed *ParsedQuery, req SearchRequest, limit int) []engineAttempt {
+	delay, ok := m.hedgeDelay(primary.Name())
+	if !ok {
+		return []engineAttempt{m.attempt(ctx, primary, parsed, req, limit)}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan engineAttempt, 2)
+	go func() {
+		done <- m.attempt(ctx, primary, parsed, req, limit)
+	}()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case a := <-done:
+		return []engineAttempt{a}
+	case <-timer.C:
+	}
+
+	log.Printf("🏁 Engine %s exceeded its p95 latency %s, hedging with %s", primary.Name(), delay, hedge.Name())
+	go func() {
+		a := m.attempt(ctx, hedge, parsed, req, limit)
+		a.hedged = true
+		done <- a
+	}()
+
+	// 一方成功后取消另一方；先完成的一方失败时继续等待另一方
+	first := <-done
+	if first.succeeded() {
+		cancel()
+	}
+	second := <-done
+	if first.succeeded() && errors.Is(second.err, context.Canceled) {
+		second.err = fmt.Errorf("%s answered first: %w", first.engine.Name(), second.err)
+	}
+	return []engineAttempt{first, second}
+}
+
+// hedgeDelay 返回启动对冲请求前的等待时间：引擎最近的 p95 延迟，不低于配置的最短等待时间
+// 未启用对冲或延迟样本不足时返回 false
+func (m *Manager) hedgeDelay(name string) (time.Duration, bool) {
+	if !m.config.IsHedgeEnabled() {
+		return 0, false
+	}
+	p95, ok := m.health.LatencyP95(name, m.config.GetHedgeMinSamples())
+	if !ok {
+		return 0, false
+	}
+	return max(p95, m.config.GetHedgeMinDelay()), true
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ErrNoInstantAnswer 所有引擎都没有给出即时答案
+var ErrNoInstantAnswer = errors.New("no instant answer found")
+
+// InstantAnswer 即时答案：百科摘要、直接答案或搜索结果页的精选摘要
+type InstantAnswer struct {
+	Query         string         `json:"query"`
+	Engine        string         `json:"engine"`
+	Heading       string         `json:"heading,omitempty"`
+	Answer        string         `json:"answer,omitempty"`
+	Abstract      string         `json:"abstract,omitempty"`
+	Definition    string         `json:"definition,omitempty"`
+	Source        string         `json:"source,omitempty"`
+	SourceURL     string         `json:"source_url,omitempty"`
+	Image         string         `json:"image,omitempty"`
+	Infobox       []InfoboxEntry `json:"infobox,omitempty"`
+	RelatedTopics []RelatedTopic `json:"related_topics,omitempty"`
+}
+
+// InfoboxEntry 信息框中的一项（如“出生日期: 1955-10-28”）
+type InfoboxEntry struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// RelatedTopic 相关主题
+type RelatedTopic struct {
+	Text string `json:"text"`
+	URL  string `json:"url,omitempty"`
+}
+
+// InstantAnswerEngine 能提供即时答案的引擎（可选接口）
+type InstantAnswerEngine interface {
+	InstantAnswer(ctx context.Context, query string) (*InstantAnswer, error)
+}
+
+// IsEmpty 判断是否没有任何实质内容
+func (a *InstantAnswer) IsEmpty() bool {
+	return a == nil || (a.Answer == "" && a.Abstract == "" && a.Definition == "" && len(a.Infobox) == 0)
+}
+
+// firstText 返回第一个非空选择器匹配的文本
+func firstText(s *goquery.Selection, selectors ...string) string {
+	for _, sel := range selectors {
+		if text := cleanText(s.Find(sel).First().Text()); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// hostOf 返回链接的域名
+func hostOf(link string) string {
+	if parsedURL, err := url.Parse(link); err == nil {
+		return strings.TrimPrefix(parsedURL.Host, "www.")
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/config"
+)
+
+// AutoDecision auto 模式的引擎选择结果
+type AutoDecision struct {
+	// Language 用于选择引擎的语言（zh、ja、ko、ru、ar、en 等）
+	Language string `json:"language"`
+	// Script 检测到的文字（han、kana、hangul、cyrillic、arabic、thai、latin）
+	Script string `json:"script,omitempty"`
+	// Source 语言的来源：language 参数或按文字检测
+	Source  string   `json:"source"`
+	Engines []string `json:"engines"`
+}
+
+// scriptLanguages 文字与语言的对应关系
+var scriptLanguages = map[string]string{
+	"han":      "zh",
+	"kana":     "ja",
+	"hangul":   "ko",
+	"cyrillic": "ru",
+	"arabic":   "ar",
+	"thai":     "th",
+	"latin":    "en",
+}
+
+// detectScript 统计查询中各类文字的字符数，返回代表查询语言的文字；含假名时视为日文
+func detectScript(text string) string {
+	counts := make(map[string]int)
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			counts["kana"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["hangul"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["cyrillic"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["arabic"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["thai"]++
+		case unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+		}
+	}
+
+	// 日文混用汉字和假名，出现假名即判断为日文
+	if counts["kana"] > 0 {
+		return "kana"
+	}
+
+	// 其他语言的查询常夹带英文词（如 "golang 教程"），有非拉丁文字时取其中最多的一种
+	best, max := "", 0
+	for _, script := range []string{"han", "hangul", "cyrillic", "arabic", "thai"} {
+		if counts[script] > max {
+			best, max = script, counts[script]
+		}
+	}
+	if best == "" && counts["latin"] > 0 {
+		best = "latin"
+	}
+	return best
+}
+
+// detectLanguage 确定查询语言：优先使用 language 参数（如 zh-CN 取 zh），否则按查询文字检测
+func detectLanguage(parsed *ParsedQuery, languageHint string) AutoDecision {
+	if hint := strings.ToLower(strings.TrimSpace(languageHint)); hint != "" {
+		lang, _, _ := strings.Cut(strings.ReplaceAll(hint, "_", "-"), "-")
+		return AutoDecision{Language: lang, Source: "language"}
+	}
+
+	// 只看查询词，site:、filetype: 等操作符中的域名不参与检测
+	var parts []string
+	for _, group := range parsed.Groups {
+		for _, term := range group {
+			parts = append(parts, term.Text)
+		}
+	}
+	for _, term := range parsed.InTitle {
+		parts = append(parts, term.Text)
+	}
+
+	script := detectScript(strings.Join(parts, " "))
+	lang, ok := scriptLanguages[script]
+	if !ok {
+		lang = "default"
+	}
+	return AutoDecision{Language: lang, Script: script, Source: "script"}
+}
+
+// resolveAutoEngines 将请求引擎中的 auto 替换为按查询语言选择的引擎，返回替换后的引擎列表和选择结果
+func (m *Manager) resolveAutoEngines(engines []string, req SearchRequest) ([]string, *AutoDecision) {
+	if !containsString(engines, config.AutoEngine) {
+		return engines, nil
+	}
+
+	decision := detectLanguage(ParseQuery(req.Query), req.Language)
+
+	// 只保留允许使用且已注册的引擎
+	for _, name := range m.config.GetAutoEngines(decision.Language) {
+		if _, ok := m.GetEngine(name); ok && m.config.IsEngineAllowed(name) {
+			decision.Engines = append(decision.Engines, name)
+		}
+	}
+
+	var resolved []string
+	for _, name := range engines {
+		if name == config.AutoEngine {
+			resolved = append(resolved, decision.Engines...)
+			continue
+		}
+		resolved = append(resolved, name)
+	}
+	return uniqueStrings(resolved), &decision
+}
@@ -2,30 +2,73 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/cliffyan/go-web-search-mcp/internal/config"
 )
 
 // Manager 搜索引擎管理器
 type Manager struct {
-	engines map[string]SearchEngine
-	config  *config.Config
-	mu      sync.RWMutex
+	engines  map[string]SearchEngine
+	config   *config.Config
+	resolver *RedirectResolver
+	health   *HealthTracker
+	cache    ResultCache
+	flights  *Coalescer
+	pipeline *Pipeline
+	// limiters 各引擎的限流器，本 Manager 的所有请求共享，不同 Manager 互不影响
+	limiters map[string]*RateLimiter
+	mu       sync.RWMutex
 }
 
 // NewManager 创建搜索引擎管理器
 func NewManager(cfg *config.Config) *Manager {
 	m := &Manager{
-		engines: make(map[string]SearchEngine),
-		config:  cfg,
+		engines:  make(map[string]SearchEngine),
+		config:   cfg,
+		flights:  NewCoalescer(),
+		limiters: newRateLimiters(cfg),
+		health: NewHealthTracker(cfg.IsCircuitBreakerEnabled(), cfg.GetCircuitBreakerThreshold(),
+			cfg.GetCircuitBreakerCooldown(), cfg.GetCircuitBreakerMaxCooldown()),
 	}
 
 	// 初始化搜索引擎
 	m.initEngines()
 
+	// 按配置顺序组装结果处理流水线，BM25 重排使用本 Manager 配置的权重，不修改全局注册表
+	pipeline, unknown := NewPipeline(cfg.GetProcessors(), NewBM25Reranker(cfg.GetBM25Weights()))
+	if len(unknown) > 0 {
+		log.Printf("⚠️ Unknown result processor(s) ignored: %v", unknown)
+	}
+	m.pipeline = pipeline
+	log.Printf("🧩 Result processors: %v", pipeline.Names())
+
+	if cfg.IsResolveRedirects() {
+		proxyURL := ""
+		if cfg.IsUseProxy() {
+			proxyURL = cfg.GetProxyURL()
+		}
+		// 跳转解析使用独立的令牌桶（速率与引擎相同），不占用搜索请求的令牌
+		m.resolver = NewRedirectResolver(proxyURL, newRateLimiters(cfg))
+	}
+
+	if cfg.IsCacheEnabled() {
+		m.cache = NewMemoryCache(cfg.GetCacheTTL(), cfg.GetCacheMaxEntries())
+		if cfg.GetCacheBackend() == "disk" {
+			disk, err := NewDiskCache(cfg.GetCacheDir(), cfg.GetCacheTTL(), cfg.GetCacheMaxEntries())
+			if err != nil {
+				log.Printf("⚠️ Disk cache unavailable, using memory cache: %v", err)
+			} else {
+				m.cache = disk
+			}
+		}
+	}
+
 	return m
 }
 
@@ -37,17 +80,27 @@ func (m *Manager) initEngines() {
 	}
 
 	// 注册 HTTP 版搜索引擎
-	m.RegisterEngine(NewBingEngine(proxyURL))
-	m.RegisterEngine(NewDuckDuckGoEngine(proxyURL))
-	m.RegisterEngine(NewBaiduEngine(proxyURL))
-	m.RegisterEngine(NewSogouEngine(proxyURL))
+	m.RegisterEngine(NewBingEngine(proxyURL, m.limiters["bing"]))
+	m.RegisterEngine(NewDuckDuckGoEngine(proxyURL, m.limiters["duckduckgo"]))
+	m.RegisterEngine(NewBaiduEngine(proxyURL, m.limiters["baidu"]))
+	m.RegisterEngine(NewSogouEngine(proxyURL, m.limiters["sogou"]))
+	m.RegisterEngine(NewSogouWeixinEngine(proxyURL, m.limiters["sogou_weixin"]))
+
+	// 注册学术搜索引擎（基于公开 API）
+	m.RegisterEngine(NewArxivEngine(proxyURL, m.limiters["arxiv"]))
+	m.RegisterEngine(NewCrossrefEngine(proxyURL, m.limiters["crossref"]))
+	m.RegisterEngine(NewSemanticScholarEngine(proxyURL, m.limiters["semantic_scholar"]))
+
+	// 注册社区讨论搜索引擎
+	m.RegisterEngine(NewHackerNewsEngine(proxyURL, m.limiters["hackernews"]))
+	m.RegisterEngine(NewRedditEngine(proxyURL, m.limiters["reddit"]))
 
 	// 注册浏览器版搜索引擎（如果启用）
 	if m.config.IsBrowserEnabled() {
 		headless := m.config.IsBrowserHeadless()
-		m.RegisterEngine(NewBrowserBingEngine(proxyURL, headless))
-		m.RegisterEngine(NewBrowserGoogleEngine(proxyURL, headless))
-		m.RegisterEngine(NewBrowserBaiduEngine(proxyURL, headless))
+		m.RegisterEngine(NewBrowserBingEngine(proxyURL, headless, m.limiters["browser_bing"]))
+		m.RegisterEngine(NewBrowserGoogleEngine(proxyURL, headless, m.limiters["browser_google"]))
+		m.RegisterEngine(NewBrowserBaiduEngine(proxyURL, headless, m.limiters["browser_baidu"]))
 		log.Printf("🌐 Browser engines enabled (headless=%v)", headless)
 	}
 
@@ -82,64 +135,492 @@ func (m *Manager) GetEngineNames() []string {
 	return names
 }
 
-// Search 执行搜索（支持多引擎）
-func (m *Manager) Search(ctx context.Context, req SearchRequest) ([]SearchResult, error) {
+// Search 执行搜索（支持多引擎），无法处理的过滤条件以警告形式返回
+// 相同的查询、引擎、数量和过滤条件在有效期内直接返回缓存结果，Fresh 为 true 时跳过缓存
+func (m *Manager) Search(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	return m.searchWithEvents(ctx, req, nil)
+}
+
+// searchWithEvents 执行搜索，emit 不为空时在确定引擎后和每个引擎完成时发送事件
+func (m *Manager) searchWithEvents(ctx context.Context, req SearchRequest, emit func(SearchEvent)) (*SearchResponse, error) {
+	// 整次搜索的时间预算，到时未完成的引擎记为超时
+	if req.TimeoutMS > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(req.TimeoutMS)*time.Millisecond)
+		defer cancel()
+	}
+
 	// 确定使用的引擎
-	engines := req.Engines
+	engines := uniqueStrings(req.Engines)
 	if len(engines) == 0 {
 		engines = []string{m.config.GetDefaultSearchEngine()}
 	}
 
+	// auto 按查询语言选择引擎
+	engines, auto := m.resolveAutoEngines(engines, req)
+	if auto != nil {
+		log.Printf("🌐 Auto engine selection: language=%s engines=%v", auto.Language, auto.Engines)
+		if len(engines) == 0 {
+			return nil, fmt.Errorf("auto: no allowed engines configured for language %s", auto.Language)
+		}
+	}
+	if emit != nil {
+		emit(SearchEvent{Type: EventStart, Engines: engines, Auto: auto})
+	}
+
 	// 设置默认 limit
 	limit := req.Limit
 	if limit <= 0 {
 		limit = 10
 	}
 
-	var allResults []SearchResult
+	// 设置默认搜索类型
+	if req.Type == "" {
+		req.Type = SearchTypeWeb
+	}
+	req.normalize()
+
+	if m.cache == nil {
+		resp, err := m.search(ctx, req, engines, limit, emit)
+		if err != nil {
+			return nil, err
+		}
+		resp.Auto = auto
+		return resp, nil
+	}
+
+	key := cacheKey(req, engines, limit)
+	if !req.Fresh {
+		if resp, ok := m.cache.Get(key); ok {
+			log.Printf("💾 Cache hit for query: %s", req.Query)
+			resp.Cached = true
+			// 相同引擎集合的缓存可能来自显式指定引擎的请求，按本次请求设置
+			resp.Auto = auto
+			if emit != nil {
+				for _, event := range cachedEngineEvents(resp) {
+					emit(event)
+				}
+			}
+			return resp, nil
+		}
+	}
+
+	resp, err := m.search(ctx, req, engines, limit, emit)
+	if err != nil {
+		return nil, err
+	}
+	resp.Auto = auto
+	// 只缓存所有引擎都成功的响应；空结果、超时后的部分结果或有引擎失败（包括由备用引擎补上）的结果下次重新搜索
+	if len(resp.Results) > 0 && ctx.Err() == nil && !hasFailedOutcome(resp.Outcomes) {
+		m.cache.Set(key, resp)
+	}
+	return resp, nil
+}
+
+// search 并发调用各引擎搜索，合并结果；emit 不为空时每个引擎（包括备用引擎）完成后立即发送其结果
+func (m *Manager) search(ctx context.Context, req SearchRequest, engines []string, limit int, emit func(SearchEvent)) (*SearchResponse, error) {
+	// 按所选引擎的能力校验请求，没有引擎能处理的搜索类型直接报错
+	capWarnings, err := m.checkCapabilities(engines, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析查询操作符，按各引擎方言转换
+	parsed := ParseQuery(req.Query)
+
+	perEngine := make(map[string][]SearchResult)
+	// 提供结果的引擎本次的搜索情况，用于计算下一页各引擎的偏移
+	pages := make(map[string]engineSearch)
+	// 按请求顺序记录每个引擎（及其备用引擎）的执行情况
+	outcomes := make([][]EngineOutcome, len(engines))
+	warnings := capWarnings
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var lastErr error
+	var unsupported []string
 
-	for _, engineName := range engines {
+	addWarnings := func(w ...string) {
+		mu.Lock()
+		warnings = append(warnings, w...)
+		mu.Unlock()
+	}
+
+	for idx, engineName := range engines {
 		// 检查引擎是否被允许
 		if !m.config.IsEngineAllowed(engineName) {
 			log.Printf("⚠️ Engine %s is not allowed, skipping", engineName)
+			outcomes[idx] = []EngineOutcome{{Engine: engineName, Status: OutcomeSkippedNotAllowed}}
 			continue
 		}
 
 		engine, ok := m.GetEngine(engineName)
 		if !ok {
 			log.Printf("⚠️ Engine %s not found, skipping", engineName)
+			outcomes[idx] = []EngineOutcome{{Engine: engineName, Status: OutcomeNotFound}}
+			continue
+		}
+
+		// 检查引擎是否支持该搜索类型
+		if !supportsType(engine, req.Type) {
+			log.Printf("⚠️ Engine %s does not support search type %s, skipping", engineName, req.Type)
+			unsupported = append(unsupported, engineName)
+			addWarnings(fmt.Sprintf("engine %s does not support search type %s; skipped", engineName, req.Type))
+			outcomes[idx] = []EngineOutcome{{
+				Engine: engineName,
+				Status: OutcomeSkippedUnsupported,
+				Error:  fmt.Sprintf("search type %s is not supported", req.Type),
+			}}
 			continue
 		}
 
+		// 主引擎失败或没有结果时，沿配置的备用链依次尝试
+		chain := append([]SearchEngine{engine}, m.fallbackEngines(engineName, req.Type, engines)...)
+
 		wg.Add(1)
-		go func(eng SearchEngine) {
+		go func(idx int, chain []SearchEngine) {
 			defer wg.Done()
 
-			results, err := eng.Search(ctx, req.Query, limit)
-			if err != nil {
-				log.Printf("❌ Search with %s failed: %v", eng.Name(), err)
-				mu.Lock()
-				lastErr = err
-				mu.Unlock()
-				return
-			}
+			for i := 0; i < len(chain); {
+				// 主引擎可以与第一个备用引擎对冲，之后的备用引擎依次尝试
+				var attempts []engineAttempt
+				if i == 0 && len(chain) > 1 {
+					attempts = m.searchHedged(ctx, chain[0], chain[1], parsed, req, limit)
+				} else {
+					attempts = []engineAttempt{m.attempt(ctx, chain[i], parsed, req, limit)}
+				}
+				i += len(attempts)
+
+				var winner *engineAttempt
+				for j := range attempts {
+					if attempts[j].succeeded() {
+						winner = &attempts[j]
+						break
+					}
+				}
 
-			mu.Lock()
-			allResults = append(allResults, results...)
-			mu.Unlock()
+				for _, a := range attempts {
+					addWarnings(a.res.warnings...)
 
-			log.Printf("✅ Search with %s returned %d results", eng.Name(), len(results))
-		}(engine)
+					outcome := newOutcome(a.engine.Name(), a.latency, len(a.res.results), a.err)
+					if a.engine != chain[0] {
+						outcome.FallbackFor = chain[0].Name()
+					}
+					outcome.Hedged = a.hedged
+					mu.Lock()
+					outcomes[idx] = append(outcomes[idx], outcome)
+					mu.Unlock()
+					if emit != nil {
+						emit(m.engineEvent(ctx, req, parsed, outcome, a.res.results, limit))
+					}
+
+					// 查询只包含引擎不支持的操作符时不算搜索失败，对冲中被取消的一方也不算
+					if a.err != nil && winner == nil && !errors.Is(a.err, errNothingToSearch) {
+						log.Printf("❌ Search with %s failed: %v", a.engine.Name(), a.err)
+						mu.Lock()
+						lastErr = a.err
+						mu.Unlock()
+					}
+				}
+
+				if winner != nil {
+					mu.Lock()
+					perEngine[winner.engine.Name()] = winner.res.results
+					pages[winner.engine.Name()] = winner.res
+					mu.Unlock()
+					return
+				}
+
+				if i < len(chain) {
+					last := attempts[len(attempts)-1]
+					reason := "returned no results"
+					if last.err != nil {
+						reason = fmt.Sprintf("failed (%v)", last.err)
+					}
+					log.Printf("↪️ Engine %s %s, falling back to %s", last.engine.Name(), reason, chain[i].Name())
+					addWarnings(fmt.Sprintf("engine %s %s; fell back to %s", last.engine.Name(), reason, chain[i].Name()))
+				}
+			}
+		}(idx, chain)
 	}
 
 	wg.Wait()
 
+	if req.TimeoutMS > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		warnings = append(warnings, fmt.Sprintf("timeout_ms=%d reached; engines still running were stopped", req.TimeoutMS))
+	}
+
+	// 合并去重并按倒数排名融合排序，再经过处理流水线（规范化、过滤、补全、去重、重排）
+	allResults := mergeResults(m.servingOrder(engines, perEngine), perEngine)
+	pc := &ProcessContext{
+		Request: req,
+		Query:   parsed,
+		// 全局域名规则叠加本次调用的规则
+		Rules: m.domainRules().Merge(req.DomainRules),
+	}
+	allResults = m.pipeline.Run(ctx, pc, allResults)
+	warnings = append(warnings, pc.Warnings...)
+	if pc.Filtered > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d result(s) removed by domain rules", pc.Filtered))
+	}
+	if pc.Dropped > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d result(s) removed by filters (empty results, ads, search pages)", pc.Dropped))
+	}
+
+	var flat []EngineOutcome
+	for _, o := range outcomes {
+		for _, outcome := range o {
+			outcome.Filtered = pc.FilteredBy[outcome.Engine]
+			outcome.Dropped = pc.DroppedBy[outcome.Engine]
+			flat = append(flat, outcome)
+		}
+	}
+
+	// 限制每个域名的结果数，让结果覆盖更多来源；limit 作用于最终列表
+	allResults, dropped := capPerDomain(allResults, req.MaxPerDomain)
+	if dropped > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d result(s) dropped by max_per_domain=%d", dropped, req.MaxPerDomain))
+	}
+
+	// 截断前计算各引擎下一页的偏移，被截掉的结果留到下一页
+	var cut []SearchResult
+	if len(allResults) > limit {
+		cut = allResults[limit:]
+		allResults = allResults[:limit]
+	}
+	nextOffsets, hasMore := nextEngineOffsets(req, engines, pages, cut)
+
 	if len(allResults) == 0 && lastErr != nil {
-		return nil, fmt.Errorf("all searches failed, last error: %w", lastErr)
+		return nil, fmt.Errorf("all searches failed (%s), last error: %w", summarizeOutcomes(flat), lastErr)
+	}
+
+	if len(allResults) == 0 && len(unsupported) > 0 && len(unsupported) == len(engines) {
+		return nil, fmt.Errorf("search type %s is not supported by engine(s): %v", req.Type, unsupported)
+	}
+
+	response := &SearchResponse{
+		Results:  allResults,
+		Warnings: warnings,
+		ServedBy: m.servingOrder(engines, perEngine),
+		Outcomes: flat,
+		Filtered: pc.Filtered,
+		Dropped:  pc.Dropped,
+	}
+	if req.GroupByDomain {
+		response.Groups = groupByDomain(allResults)
+	}
+	if hasMore {
+		response.NextOffset = req.Offset + limit
+		response.NextCursor = EncodeCursor(response.NextOffset, nextOffsets)
 	}
 
-	return allResults, nil
+	return response, nil
+}
+
+// engineSearch 单个引擎的搜索结果
+type engineSearch struct {
+	results []SearchResult
+	// full 引擎返回了满一页，可能还有更多结果
+	full bool
+	// warnings 需要提示调用方的警告
+	warnings []string
+	// fetched 引擎本次返回的原始结果数（后置过滤前）
+	fetched int
+	// positions results 中每条结果在原始结果中的位置，翻页时据此计算已消费的条数
+	positions []int
+}
+
+// searchEngine 使用单个引擎搜索：转换查询方言、执行搜索、解析跳转链接并做后置过滤
+func (m *Manager) searchEngine(ctx context.Context, engine SearchEngine, parsed *ParsedQuery, req SearchRequest, limit int) (engineSearch, error) {
+	name := engine.Name()
+	var out engineSearch
+	// 游标记录了各引擎已消费的条数，每个引擎从自己的位置继续
+	req.Offset = req.engineOffset(name)
+
+	// 引擎无法处理的过滤条件仍然执行搜索，但提示调用方结果未经过滤
+	if missing := unsupportedFilters(engine, req.SearchOptions); len(missing) > 0 {
+		log.Printf("⚠️ Engine %s cannot honor filter(s) %v, ignored", name, missing)
+		out.warnings = append(out.warnings, fmt.Sprintf("engine %s cannot honor filter(s): %s; ignored", name, strings.Join(missing, ", ")))
+	}
+	for _, warning := range capabilityWarnings(engine, req, limit) {
+		log.Printf("⚠️ %s", warning)
+		out.warnings = append(out.warnings, warning)
+	}
+
+	// 引擎不支持的操作符从查询中去掉，搜索后再过滤结果
+	query, postOps := parsed.Translate(engine.QueryDialect())
+	if query == "" {
+		log.Printf("⚠️ Engine %s has nothing to search after removing unsupported operators, skipping", name)
+		out.warnings = append(out.warnings, fmt.Sprintf("engine %s cannot handle query operator(s): %s; skipped", name, strings.Join(postOps, ", ")))
+		return out, errNothingToSearch
+	}
+	if len(postOps) > 0 {
+		log.Printf("⚠️ Engine %s cannot handle operator(s) %v, applying as post-filter", name, postOps)
+		out.warnings = append(out.warnings, fmt.Sprintf("engine %s cannot handle query operator(s): %s; applied as post-filter", name, strings.Join(postOps, ", ")))
+	}
+
+	// 并发的相同请求合并为一次上游搜索
+	key := flightKey(name, query, limit, req.SearchOptions)
+	results, err := m.flights.Do(ctx, key, func(ctx context.Context) ([]SearchResult, error) {
+		// 熔断中的引擎直接跳过，避免反复等待超时或验证码
+		if ok, until := m.health.Allow(name); !ok {
+			log.Printf("⚡ Engine %s circuit is open, skipping", name)
+			return nil, fmt.Errorf("engine %s %w until %s", name, ErrCircuitOpen, until.Format(time.RFC3339))
+		}
+
+		// 引擎自己的截止时间，超时计为引擎失败
+		timeout := m.config.GetEngineTimeout(name)
+		engineCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		start := time.Now()
+		results, err := engine.Search(engineCtx, query, limit, req.SearchOptions)
+		if err != nil {
+			// 所有调用方都已取消时不计入引擎失败
+			if ctx.Err() != nil {
+				m.health.Release(name)
+				return nil, err
+			}
+			if engineCtx.Err() != nil {
+				err = fmt.Errorf("engine %s exceeded deadline %s: %w", name, timeout, err)
+			}
+			m.health.RecordFailure(name, err)
+			return nil, err
+		}
+		m.health.RecordSuccess(name)
+		m.health.RecordLatency(name, time.Since(start))
+
+		// 先解析跳转链接，站点过滤、域名规则和跨引擎去重依赖真实地址；
+		// 解析受引擎剩余的截止时间限制，到时未解析的链接保持原样
+		if m.resolver != nil {
+			m.resolver.ResolveAll(engineCtx, results)
+		}
+		return results, nil
+	})
+	if err != nil {
+		return out, err
+	}
+
+	// 引擎返回满一页说明后面可能还有结果
+	out.full = len(results) >= limit
+
+	out.fetched = len(results)
+	for i, r := range results {
+		if len(postOps) == 0 || parsed.matches(r, postOps) {
+			out.results = append(out.results, r)
+			out.positions = append(out.positions, i)
+		}
+	}
+
+	log.Printf("✅ Search with %s returned %d results", name, len(out.results))
+	return out, nil
+}
+
+// domainRules 返回配置中的全局域名规则
+func (m *Manager) domainRules() DomainRules {
+	cfg := m.config.GetDomainRules()
+	return DomainRules{Block: cfg.Block, Allow: cfg.Allow, Boost: cfg.Boost}
+}
+
+// fallbackEngines 返回引擎可用的备用引擎：跳过未允许、未注册、不支持该搜索类型以及本次已请求的引擎
+func (m *Manager) fallbackEngines(name, searchType string, requested []string) []SearchEngine {
+	var chain []SearchEngine
+	for _, fallback := range m.config.GetFallbacks(name) {
+		if containsString(requested, fallback) || !m.config.IsEngineAllowed(fallback) {
+			continue
+		}
+		engine, ok := m.GetEngine(fallback)
+		if !ok || !supportsType(engine, searchType) {
+			continue
+		}
+		chain = append(chain, engine)
+	}
+	return chain
+}
+
+// servingOrder 返回实际提供结果的引擎，按请求顺序排列，备用引擎排在其主引擎的位置
+func (m *Manager) servingOrder(requested []string, perEngine map[string][]SearchResult) []string {
+	var order []string
+	for _, name := range requested {
+		candidates := append([]string{name}, m.config.GetFallbacks(name)...)
+		for _, candidate := range candidates {
+			if _, ok := perEngine[candidate]; ok && !containsString(order, candidate) {
+				order = append(order, candidate)
+				break
+			}
+		}
+	}
+	return order
+}
+
+// Health 返回所有已注册引擎的健康状况和熔断状态
+func (m *Manager) Health() []EngineHealth {
+	return m.health.Snapshot(m.GetEngineNames())
+}
+
+// DefaultInstantAnswerEngines 默认依次尝试的即时答案引擎：先用轻量的 API，再用 Bing 精选摘要
+var DefaultInstantAnswerEngines = []string{"duckduckgo", "bing"}
+
+// instantAnswerTimeout 单个引擎获取即时答案的超时时间
+const instantAnswerTimeout = 10 * time.Second
+
+// InstantAnswer 依次尝试各引擎，返回第一个有内容的即时答案
+func (m *Manager) InstantAnswer(ctx context.Context, query string, engines []string) (*InstantAnswer, error) {
+	if len(engines) == 0 {
+		engines = DefaultInstantAnswerEngines
+	}
+
+	var lastErr error
+	answered := false
+	for _, engineName := range engines {
+		if !m.config.IsEngineAllowed(engineName) {
+			log.Printf("⚠️ Engine %s is not allowed, skipping", engineName)
+			continue
+		}
+
+		engine, ok := m.GetEngine(engineName)
+		if !ok {
+			log.Printf("⚠️ Engine %s not found, skipping", engineName)
+			continue
+		}
+
+		answerer, ok := engine.(InstantAnswerEngine)
+		if !ok {
+			log.Printf("⚠️ Engine %s does not provide instant answers, skipping", engineName)
+			continue
+		}
+
+		engineCtx, cancel := context.WithTimeout(ctx, instantAnswerTimeout)
+		answer, err := answerer.InstantAnswer(engineCtx, query)
+		cancel()
+		if err != nil {
+			log.Printf("❌ Instant answer with %s failed: %v", engineName, err)
+			lastErr = err
+			continue
+		}
+
+		answered = true
+		if !answer.IsEmpty() {
+			log.Printf("✅ Instant answer found by %s", engineName)
+			return answer, nil
+		}
+	}
+
+	// 所有引擎都失败时返回错误，有引擎正常响应但没有内容时返回 ErrNoInstantAnswer
+	if !answered && lastErr != nil {
+		return nil, fmt.Errorf("all instant answer engines failed, last error: %w", lastErr)
+	}
+	return nil, ErrNoInstantAnswer
+}
+
+// uniqueStrings 去掉重复项并保持顺序
+func uniqueStrings(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
 }
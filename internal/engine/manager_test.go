@@ -0,0 +1,451 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/config"
+)
+
+// stubEngine 按偏移返回固定结果的测试引擎
+type stubEngine struct {
+	name    string
+	results []SearchResult
+	err     error
+	// delay 返回结果前等待的时间，上下文结束时提前返回
+	delay time.Duration
+}
+
+func (e *stubEngine) Name() string { return e.name }
+
+func (e *stubEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryWeb,
+		Verticals:   webOnly,
+		Filters:     []string{},
+		Pagination:  true,
+		RegionFocus: RegionGlobal,
+	}
+}
+
+func (e *stubEngine) QueryDialect() QueryDialect { return standardDialect }
+
+func (e *stubEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	if e.delay > 0 {
+		select {
+		case <-time.After(e.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	return window(e.results, opts.Offset, limit), nil
+}
+
+// newStubEngine 创建返回 n 条结果的测试引擎，每条结果位于不同的域名
+func newStubEngine(name string, n int) *stubEngine {
+	e := &stubEngine{name: name}
+	for i := 0; i < n; i++ {
+		e.results = append(e.results, SearchResult{
+			Title:       fmt.Sprintf("%s result %d", name, i),
+			URL:         fmt.Sprintf("https://%s-%d.example.com/page", name, i),
+			Description: fmt.Sprintf("description %d", i),
+			Engine:      name,
+		})
+	}
+	return e
+}
+
+// newTestManager 创建不访问网络的 Manager：关闭缓存、浏览器引擎和跳转解析，用测试引擎替换同名引擎
+func newTestManager(t *testing.T, engines ...SearchEngine) *Manager {
+	t.Helper()
+	return newTestManagerWith(t, nil, engines...)
+}
+
+// newTestManagerWith 同 newTestManager，创建前用 configure 修改配置（修改 map 时需要替换而不是原地修改）
+func newTestManagerWith(t *testing.T, configure func(cfg *config.Config), engines ...SearchEngine) *Manager {
+	t.Helper()
+	cfg := *config.DefaultConfig
+	cfg.Cache.Enabled = false
+	cfg.Browser.Enabled = false
+	cfg.Search.ResolveRedirects = false
+	if configure != nil {
+		configure(&cfg)
+	}
+
+	m := NewManager(&cfg)
+	for _, e := range engines {
+		m.RegisterEngine(e)
+	}
+	return m
+}
+
+// hasWarning 判断警告中是否包含指定内容
+func hasWarning(warnings []string, substr string) bool {
+	for _, w := range warnings {
+		if strings.Contains(w, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// findOutcome 返回指定引擎的执行情况
+func findOutcome(t *testing.T, outcomes []EngineOutcome, engine string) EngineOutcome {
+	t.Helper()
+	for _, o := range outcomes {
+		if o.Engine == engine {
+			return o
+		}
+	}
+	t.Fatalf("no outcome for engine %s in %+v", engine, outcomes)
+	return EngineOutcome{}
+}
+
+func TestSearchFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary *stubEngine
+		reason  string
+	}{
+		{
+			name:    "primary fails",
+			primary: &stubEngine{name: "bing", err: errors.New("boom")},
+			reason:  "engine bing failed (boom); fell back to duckduckgo",
+		},
+		{
+			name:    "primary returns nothing",
+			primary: newStubEngine("bing", 0),
+			reason:  "engine bing returned no results; fell back to duckduckgo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManagerWith(t, func(cfg *config.Config) {
+				cfg.Search.Fallbacks = map[string][]string{"bing": {"duckduckgo"}}
+			}, tt.primary, newStubEngine("duckduckgo", 3))
+
+			resp, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 3, Engines: []string{"bing"}})
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if len(resp.Results) != 3 || resp.Results[0].Engine != "duckduckgo" {
+				t.Errorf("Results = %+v, want 3 results from duckduckgo", resp.Results)
+			}
+			if len(resp.ServedBy) != 1 || resp.ServedBy[0] != "duckduckgo" {
+				t.Errorf("ServedBy = %v, want [duckduckgo]", resp.ServedBy)
+			}
+			if !hasWarning(resp.Warnings, tt.reason) {
+				t.Errorf("Warnings = %v, want %q", resp.Warnings, tt.reason)
+			}
+			fallback := findOutcome(t, resp.Outcomes, "duckduckgo")
+			if fallback.Status != OutcomeOK || fallback.FallbackFor != "bing" || fallback.ResultCount != 3 {
+				t.Errorf("fallback outcome = %+v", fallback)
+			}
+		})
+	}
+}
+
+func TestSearchFallbackChain(t *testing.T) {
+	m := newTestManagerWith(t, func(cfg *config.Config) {
+		cfg.Search.Fallbacks = map[string][]string{"bing": {"duckduckgo", "baidu"}}
+	},
+		&stubEngine{name: "bing", err: errors.New("boom")},
+		newStubEngine("duckduckgo", 0),
+		newStubEngine("baidu", 2),
+	)
+
+	resp, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 5, Engines: []string{"bing"}})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.ServedBy) != 1 || resp.ServedBy[0] != "baidu" {
+		t.Errorf("ServedBy = %v, want [baidu]", resp.ServedBy)
+	}
+	for _, want := range []string{"engine bing failed (boom); fell back to duckduckgo", "engine duckduckgo returned no results; fell back to baidu"} {
+		if !hasWarning(resp.Warnings, want) {
+			t.Errorf("Warnings = %v, want %q", resp.Warnings, want)
+		}
+	}
+	var got []string
+	for _, o := range resp.Outcomes {
+		got = append(got, o.Engine+":"+o.Status)
+	}
+	want := []string{"bing:error", "duckduckgo:ok", "baidu:ok"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Outcomes = %v, want %v", got, want)
+	}
+}
+
+func TestSearchFallbackSkipsRequestedEngine(t *testing.T) {
+	m := newTestManagerWith(t, func(cfg *config.Config) {
+		cfg.Search.Fallbacks = map[string][]string{"bing": {"duckduckgo"}}
+	}, &stubEngine{name: "bing", err: errors.New("boom")}, newStubEngine("duckduckgo", 2))
+
+	resp, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 5, Engines: []string{"bing", "duckduckgo"}})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.Results) != 2 || len(resp.ServedBy) != 1 || resp.ServedBy[0] != "duckduckgo" {
+		t.Errorf("Results = %d from %v, want duckduckgo to run once as a requested engine", len(resp.Results), resp.ServedBy)
+	}
+	if o := findOutcome(t, resp.Outcomes, "duckduckgo"); len(resp.Outcomes) != 2 || o.FallbackFor != "" {
+		t.Errorf("Outcomes = %+v, want duckduckgo once without FallbackFor", resp.Outcomes)
+	}
+	if hasWarning(resp.Warnings, "fell back") {
+		t.Errorf("Warnings = %v, want no fallback", resp.Warnings)
+	}
+}
+
+func TestSearchFallbackAllFail(t *testing.T) {
+	m := newTestManagerWith(t, func(cfg *config.Config) {
+		cfg.Search.Fallbacks = map[string][]string{"bing": {"duckduckgo"}}
+	}, &stubEngine{name: "bing", err: errors.New("boom")}, &stubEngine{name: "duckduckgo", err: errors.New("down")})
+
+	_, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 5, Engines: []string{"bing"}})
+	if err == nil {
+		t.Fatal("Search() error = nil, want error when the whole chain fails")
+	}
+	if !strings.Contains(err.Error(), "bing: error, duckduckgo: error") || !strings.Contains(err.Error(), "down") {
+		t.Errorf("error = %v, want both engines summarized and the last fallback's error", err)
+	}
+}
+
+func TestSearchOutcomes(t *testing.T) {
+	m := newTestManagerWith(t, func(cfg *config.Config) {
+		cfg.Search.AllowedEngines = []string{"bing", "duckduckgo", "baidu", "browser_google"}
+	},
+		newStubEngine("bing", 2),
+		&stubEngine{name: "duckduckgo", err: errors.New("captcha required")},
+		&stubEngine{name: "baidu", err: errors.New("unexpected status 500")},
+		newStubEngine("sogou", 2),
+	)
+
+	resp, err := m.Search(context.Background(), SearchRequest{
+		Query:   "golang",
+		Limit:   5,
+		Engines: []string{"bing", "duckduckgo", "baidu", "sogou", "browser_google"},
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	want := []struct {
+		engine, status string
+		count          int
+	}{
+		{"bing", OutcomeOK, 2},
+		{"duckduckgo", OutcomeCaptcha, 0},
+		{"baidu", OutcomeError, 0},
+		{"sogou", OutcomeSkippedNotAllowed, 0},
+		{"browser_google", OutcomeNotFound, 0},
+	}
+	if len(resp.Outcomes) != len(want) {
+		t.Fatalf("Outcomes = %+v, want %d entries", resp.Outcomes, len(want))
+	}
+	for i, w := range want {
+		o := resp.Outcomes[i]
+		if o.Engine != w.engine || o.Status != w.status || o.ResultCount != w.count {
+			t.Errorf("Outcomes[%d] = %+v, want %s %s with %d result(s)", i, o, w.engine, w.status, w.count)
+		}
+	}
+	if o := resp.Outcomes[2]; o.Error != "unexpected status 500" {
+		t.Errorf("baidu error = %q", o.Error)
+	}
+}
+
+func TestSearchOutcomeCircuitOpen(t *testing.T) {
+	m := newTestManager(t, newStubEngine("bing", 2))
+	for i := 0; i < m.config.GetCircuitBreakerThreshold(); i++ {
+		m.health.RecordFailure("bing", errors.New("boom"))
+	}
+
+	_, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 5, Engines: []string{"bing"}})
+	if err == nil || !strings.Contains(err.Error(), "bing: "+OutcomeSkippedCircuitOpen) {
+		t.Errorf("Search() error = %v, want bing skipped by the open circuit", err)
+	}
+}
+
+func TestSearchEngineDeadline(t *testing.T) {
+	m := newTestManagerWith(t, func(cfg *config.Config) {
+		cfg.Search.EngineTimeoutsMS = map[string]int{"bing": 20}
+	}, &stubEngine{name: "bing", delay: time.Minute}, newStubEngine("duckduckgo", 2))
+
+	start := time.Now()
+	resp, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 5, Engines: []string{"bing", "duckduckgo"}})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Search() took %s, want bing stopped at its 20ms deadline", elapsed)
+	}
+	if o := findOutcome(t, resp.Outcomes, "bing"); o.Status != OutcomeTimeout {
+		t.Errorf("bing outcome = %+v, want %s", o, OutcomeTimeout)
+	}
+	if len(resp.Results) != 2 || len(resp.ServedBy) != 1 || resp.ServedBy[0] != "duckduckgo" {
+		t.Errorf("Results = %d from %v, want 2 from duckduckgo", len(resp.Results), resp.ServedBy)
+	}
+}
+
+func TestSearchTimeoutBudget(t *testing.T) {
+	m := newTestManager(t, &stubEngine{name: "bing", delay: time.Minute}, newStubEngine("duckduckgo", 2))
+
+	start := time.Now()
+	resp, err := m.Search(context.Background(), SearchRequest{
+		Query:     "golang",
+		Limit:     5,
+		Engines:   []string{"bing", "duckduckgo"},
+		TimeoutMS: 50,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Search() took %s, want it to return after timeout_ms", elapsed)
+	}
+	if len(resp.Results) != 2 {
+		t.Errorf("Results = %d, want the 2 results duckduckgo returned in time", len(resp.Results))
+	}
+	if !hasWarning(resp.Warnings, "timeout_ms=50 reached") {
+		t.Errorf("Warnings = %v, want the timeout_ms warning", resp.Warnings)
+	}
+	if o := findOutcome(t, resp.Outcomes, "bing"); o.Status != OutcomeTimeout {
+		t.Errorf("bing outcome = %+v, want %s", o, OutcomeTimeout)
+	}
+}
+
+// newHedgeManager 创建启用对冲的 Manager，bing 的备用引擎为 duckduckgo，并预置 bing 的延迟样本
+func newHedgeManager(t *testing.T, samples int, engines ...SearchEngine) *Manager {
+	t.Helper()
+	m := newTestManagerWith(t, func(cfg *config.Config) {
+		cfg.Search.Fallbacks = map[string][]string{"bing": {"duckduckgo"}}
+		cfg.Search.Hedge = config.HedgeConfig{Enabled: true, MinSamples: 3, MinDelayMS: 20}
+	}, engines...)
+	for i := 0; i < samples; i++ {
+		m.health.RecordLatency("bing", 10*time.Millisecond)
+	}
+	return m
+}
+
+func TestSearchHedgeWins(t *testing.T) {
+	m := newHedgeManager(t, 3, &stubEngine{name: "bing", delay: time.Minute}, newStubEngine("duckduckgo", 2))
+
+	start := time.Now()
+	resp, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 5, Engines: []string{"bing"}})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Search() took %s, want the hedge to answer first", elapsed)
+	}
+	if len(resp.ServedBy) != 1 || resp.ServedBy[0] != "duckduckgo" {
+		t.Errorf("ServedBy = %v, want [duckduckgo]", resp.ServedBy)
+	}
+	hedge := findOutcome(t, resp.Outcomes, "duckduckgo")
+	if hedge.Status != OutcomeOK || !hedge.Hedged || hedge.FallbackFor != "bing" {
+		t.Errorf("hedge outcome = %+v, want ok, hedged, fallback for bing", hedge)
+	}
+	primary := findOutcome(t, resp.Outcomes, "bing")
+	if primary.Status != OutcomeCanceled || !strings.Contains(primary.Error, "duckduckgo answered first") {
+		t.Errorf("bing outcome = %+v, want canceled after duckduckgo answered", primary)
+	}
+}
+
+func TestSearchHedgeNeedsSamples(t *testing.T) {
+	m := newHedgeManager(t, 2, &stubEngine{name: "bing", delay: 100 * time.Millisecond, results: newStubEngine("bing", 2).results},
+		newStubEngine("duckduckgo", 2))
+
+	resp, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 5, Engines: []string{"bing"}})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.Outcomes) != 1 || resp.Outcomes[0].Engine != "bing" || resp.Outcomes[0].Status != OutcomeOK {
+		t.Errorf("Outcomes = %+v, want only bing without enough latency samples to hedge", resp.Outcomes)
+	}
+}
+
+func TestSearchHedgePrimaryFast(t *testing.T) {
+	m := newHedgeManager(t, 3, newStubEngine("bing", 2), newStubEngine("duckduckgo", 2))
+
+	resp, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 5, Engines: []string{"bing"}})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.Outcomes) != 1 || resp.Outcomes[0].Hedged {
+		t.Errorf("Outcomes = %+v, want bing to answer before the hedge delay", resp.Outcomes)
+	}
+}
+
+func TestSearchCountsDomainRulesAndFiltersSeparately(t *testing.T) {
+	bing := newStubEngine("bing", 3)
+	bing.results[1].Title = "推广 " + bing.results[1].Title
+	m := newTestManager(t, bing, newStubEngine("duckduckgo", 2))
+
+	resp, err := m.Search(context.Background(), SearchRequest{
+		Query:       "golang",
+		Limit:       10,
+		Engines:     []string{"bing", "duckduckgo"},
+		DomainRules: DomainRules{Block: []string{"bing-0.example.com", "duckduckgo-1.example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Errorf("Results = %d, want 2", len(resp.Results))
+	}
+	if resp.Filtered != 2 || resp.Dropped != 1 {
+		t.Errorf("Filtered = %d, Dropped = %d; want 2 by domain rules and 1 ad", resp.Filtered, resp.Dropped)
+	}
+	if o := findOutcome(t, resp.Outcomes, "bing"); o.Filtered != 1 || o.Dropped != 1 {
+		t.Errorf("bing outcome = %+v, want 1 filtered and 1 dropped", o)
+	}
+	if o := findOutcome(t, resp.Outcomes, "duckduckgo"); o.Filtered != 1 || o.Dropped != 0 {
+		t.Errorf("duckduckgo outcome = %+v, want 1 filtered", o)
+	}
+	if !hasWarning(resp.Warnings, "2 result(s) removed by domain rules") || !hasWarning(resp.Warnings, "1 result(s) removed by filters") {
+		t.Errorf("Warnings = %v", resp.Warnings)
+	}
+}
+
+func TestSearchCachesOnlyWhenAllEnginesSucceed(t *testing.T) {
+	tests := []struct {
+		name       string
+		bing       *stubEngine
+		fallbacks  map[string][]string
+		wantCached bool
+	}{
+		{"all ok", newStubEngine("bing", 2), nil, true},
+		{"one engine fails", &stubEngine{name: "bing", err: errors.New("boom")}, nil, false},
+		{"captcha", &stubEngine{name: "bing", err: errors.New("captcha required")}, nil, false},
+		{"served by fallback", &stubEngine{name: "bing", err: errors.New("boom")}, map[string][]string{"bing": {"baidu"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManagerWith(t, func(cfg *config.Config) {
+				cfg.Cache.Enabled = true
+				cfg.Cache.Backend = "memory"
+				cfg.Search.Fallbacks = tt.fallbacks
+			}, tt.bing, newStubEngine("duckduckgo", 2), newStubEngine("baidu", 2))
+
+			req := SearchRequest{Query: "golang", Limit: 5, Engines: []string{"bing", "duckduckgo"}}
+			if _, err := m.Search(context.Background(), req); err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			resp, err := m.Search(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if resp.Cached != tt.wantCached {
+				t.Errorf("second Search() Cached = %v, want %v", resp.Cached, tt.wantCached)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// rrfK 倒数排名融合的平滑常数，取常用值 60
+const rrfK = 60
+
+// trackingParams 规范化 URL 时去掉的跟踪参数（utm_* 另行处理）
+var trackingParams = map[string]bool{
+	"gclid": true, "fbclid": true, "msclkid": true, "yclid": true, "spm": true,
+}
+
+// EngineRank 结果在某个引擎中的排名（从 1 开始）
+type EngineRank struct {
+	Engine string `json:"engine"`
+	Rank   int    `json:"rank"`
+}
+
+// canonicalURL 规范化 URL 用于去重：统一协议、去掉 www、默认端口、片段、末尾斜杠和跟踪参数，参数排序
+func canonicalURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.TrimSpace(raw)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	path := strings.TrimRight(u.EscapedPath(), "/")
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "utm_") || trackingParams[lower] {
+			query.Del(key)
+		}
+	}
+
+	// 协议统一视为 https，http 与 https 版本按同一页面处理
+	canonical := "https://" + host + path
+	if encoded := query.Encode(); encoded != "" {
+		canonical += "?" + encoded
+	}
+	return canonical
+}
+
+// mergeResults 按引擎顺序合并各引擎结果：规范化 URL 去重，记录每个来源引擎及排名，按倒数排名融合（RRF）排序
+func mergeResults(engineOrder []string, perEngine map[string][]SearchResult) []SearchResult {
+	var merged []SearchResult
+	index := make(map[string]int)
+
+	for _, name := range engineOrder {
+		for i, r := range perEngine[name] {
+			rank := i + 1
+			key := canonicalURL(r.URL)
+
+			if pos, ok := index[key]; ok {
+				existing := &merged[pos]
+				// 同一引擎内的重复结果只保留排名最高的一次
+				if !hasEngine(existing.Engines, name) {
+					existing.Engines = append(existing.Engines, EngineRank{Engine: name, Rank: rank})
+					existing.RRFScore += 1.0 / float64(rrfK+rank)
+				}
+				mergeFields(existing, r)
+				continue
+			}
+
+			r.Engines = []EngineRank{{Engine: name, Rank: rank}}
+			r.RRFScore = 1.0 / float64(rrfK+rank)
+			index[key] = len(merged)
+			merged = append(merged, r)
+		}
+	}
+
+	// 稳定排序，分数相同时保持引擎顺序和原始排名
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].RRFScore > merged[j].RRFScore
+	})
+
+	return merged
+}
+
+// mergeFields 用重复结果补全缺失字段，描述取更完整的一份
+func mergeFields(dst *SearchResult, src SearchResult) {
+	if len([]rune(src.Description)) > len([]rune(dst.Description)) {
+		dst.Description = src.Description
+	}
+	if dst.Source == "" {
+		dst.Source = src.Source
+	}
+	if dst.PublishedAt == "" {
+		dst.PublishedAt = src.PublishedAt
+	}
+	if dst.Publisher == "" {
+		dst.Publisher = src.Publisher
+	}
+	if dst.Thumbnail == "" {
+		dst.Thumbnail = src.Thumbnail
+	}
+	if dst.OriginalURL == "" && src.OriginalURL != "" {
+		dst.OriginalURL = src.OriginalURL
+	}
+}
+
+func hasEngine(ranks []EngineRank, name string) bool {
+	for _, r := range ranks {
+		if r.Engine == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://www.Example.com/a/", "https://example.com/a"},
+		{"http://example.com/a", "https://example.com/a"},
+		{"https://example.com:443/a#top", "https://example.com/a"},
+		{"http://example.com:80/", "https://example.com"},
+		{"https://example.com:8080/a", "https://example.com:8080/a"},
+		{"https://example.com/a?b=2&a=1", "https://example.com/a?a=1&b=2"},
+		{"https://example.com/a?utm_source=x&UTM_Medium=y&gclid=1&fbclid=2&id=3", "https://example.com/a?id=3"},
+		{"https://example.com/a%20b", "https://example.com/a%20b"},
+		{" not a url ", "not a url"},
+	}
+	for _, tt := range tests {
+		if got := canonicalURL(tt.raw); got != tt.want {
+			t.Errorf("canonicalURL(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestMergeResults(t *testing.T) {
+	perEngine := map[string][]SearchResult{
+		"bing": {
+			{URL: "https://a.example.com/", Description: "short"},
+			{URL: "https://b.example.com/"},
+			{URL: "https://c.example.com/"},
+		},
+		"duckduckgo": {
+			{URL: "https://c.example.com/"},
+			{URL: "http://www.a.example.com", Description: "a longer description", Publisher: "A"},
+			// 同一引擎内的重复结果不重复计分
+			{URL: "https://c.example.com/?utm_source=ddg"},
+		},
+	}
+
+	merged := mergeResults([]string{"bing", "duckduckgo"}, perEngine)
+
+	var urls []string
+	for _, r := range merged {
+		urls = append(urls, r.URL)
+	}
+	// a: 1/61+1/62，c: 1/63+1/61，b: 1/62
+	want := []string{"https://a.example.com/", "https://c.example.com/", "https://b.example.com/"}
+	if !reflect.DeepEqual(urls, want) {
+		t.Fatalf("order = %v, want %v", urls, want)
+	}
+
+	a := merged[0]
+	if !reflect.DeepEqual(a.Engines, []EngineRank{{"bing", 1}, {"duckduckgo", 2}}) {
+		t.Errorf("a.Engines = %v", a.Engines)
+	}
+	if a.Description != "a longer description" || a.Publisher != "A" {
+		t.Errorf("fields not merged: %+v", a)
+	}
+	if want := 1.0/61 + 1.0/62; math.Abs(a.RRFScore-want) > 1e-12 {
+		t.Errorf("a.RRFScore = %v, want %v", a.RRFScore, want)
+	}
+
+	c := merged[1]
+	if !reflect.DeepEqual(c.Engines, []EngineRank{{"bing", 3}, {"duckduckgo", 1}}) {
+		t.Errorf("c.Engines = %v", c.Engines)
+	}
+}
+
+func TestMergeResultsTiesKeepEngineOrder(t *testing.T) {
+	perEngine := map[string][]SearchResult{
+		"bing":       {{URL: "https://bing.example.com/"}},
+		"duckduckgo": {{URL: "https://ddg.example.com/"}},
+	}
+	merged := mergeResults([]string{"duckduckgo", "bing"}, perEngine)
+	if len(merged) != 2 || merged[0].URL != "https://ddg.example.com/" {
+		t.Errorf("merged = %+v, want duckduckgo first", merged)
+	}
+}
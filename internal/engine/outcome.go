@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 引擎执行状态
+const (
+	OutcomeOK                 = "ok"
+	OutcomeError              = "error"
+	OutcomeCaptcha            = "captcha"
+	OutcomeTimeout            = "timeout"
+	OutcomeSkippedNotAllowed  = "skipped-not-allowed"
+	OutcomeNotFound           = "not-found"
+	OutcomeSkippedUnsupported = "skipped-unsupported"
+	OutcomeSkippedCircuitOpen = "skipped-circuit-open"
+	OutcomeCanceled           = "canceled"
+)
+
+// errNothingToSearch 去掉引擎不支持的操作符后查询为空
+var errNothingToSearch = errors.New("nothing to search after removing unsupported operators")
+
+// EngineOutcome 单个引擎本次搜索的执行情况
+type EngineOutcome struct {
+	Engine      string `json:"engine"`
+	Status      string `json:"status"`
+	LatencyMS   int64  `json:"latency_ms"`
+	ResultCount int    `json:"result_count"`
+	// Filtered 被域名规则过滤掉的结果数
+	Filtered int `json:"filtered,omitempty"`
+	// Dropped 被处理器去掉的结果数（如 filter 去掉的空结果、广告、搜索引擎结果页）
+	Dropped int    `json:"dropped,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// FallbackFor 作为哪个引擎的备用引擎执行
+	FallbackFor string `json:"fallback_for,omitempty"`
+	// Hedged 主引擎超过 p95 延迟仍未返回时作为对冲请求启动
+	Hedged bool `json:"hedged,omitempty"`
+}
+
+// newOutcome 根据搜索结果和错误生成执行情况
+func newOutcome(engine string, latency time.Duration, count int, err error) EngineOutcome {
+	outcome := EngineOutcome{
+		Engine:      engine,
+		Status:      OutcomeOK,
+		LatencyMS:   latency.Milliseconds(),
+		ResultCount: count,
+	}
+	if err != nil {
+		outcome.Status = classifyError(err)
+		outcome.Error = err.Error()
+	}
+	return outcome
+}
+
+// classifyError 将引擎错误归类为验证码、超时、取消、熔断跳过或一般错误
+func classifyError(err error) string {
+	switch {
+	case errors.Is(err, ErrCircuitOpen):
+		return OutcomeSkippedCircuitOpen
+	case errors.Is(err, errNothingToSearch):
+		return OutcomeSkippedUnsupported
+	case isCaptchaError(err):
+		return OutcomeCaptcha
+	case isTimeoutError(err):
+		return OutcomeTimeout
+	case errors.Is(err, context.Canceled):
+		return OutcomeCanceled
+	}
+	return OutcomeError
+}
+
+// isTimeoutError 判断是否为超时错误（上下文超时或网络超时）
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// hasFailedOutcome 是否有引擎搜索失败（出错、验证码、超时或熔断跳过）；对冲中被取消、未允许或不支持的引擎不算
+func hasFailedOutcome(outcomes []EngineOutcome) bool {
+	for _, o := range outcomes {
+		switch o.Status {
+		case OutcomeError, OutcomeCaptcha, OutcomeTimeout, OutcomeSkippedCircuitOpen:
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeOutcomes 汇总失败的引擎，用于全部失败时的错误信息
+func summarizeOutcomes(outcomes []EngineOutcome) string {
+	var parts []string
+	for _, o := range outcomes {
+		if o.Status != OutcomeOK {
+			parts = append(parts, fmt.Sprintf("%s: %s", o.Engine, o.Status))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
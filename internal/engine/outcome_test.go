@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"circuit open", fmt.Errorf("engine bing %w until later", ErrCircuitOpen), OutcomeSkippedCircuitOpen},
+		{"nothing to search", errNothingToSearch, OutcomeSkippedUnsupported},
+		{"captcha", errors.New("baidu returned a captcha page"), OutcomeCaptcha},
+		{"duckduckgo challenge", fmt.Errorf("html: %w", errDuckDuckGoChallenge), OutcomeCaptcha},
+		{"deadline", fmt.Errorf("request failed: %w", context.DeadlineExceeded), OutcomeTimeout},
+		{"network timeout", &net.DNSError{Err: "i/o timeout", IsTimeout: true}, OutcomeTimeout},
+		{"canceled", fmt.Errorf("request failed: %w", context.Canceled), OutcomeCanceled},
+		{"other", errors.New("unexpected status 500"), OutcomeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyError(tt.err); got != tt.want {
+				t.Errorf("classifyError(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeOutcomes(t *testing.T) {
+	outcomes := []EngineOutcome{
+		{Engine: "bing", Status: OutcomeOK},
+		{Engine: "baidu", Status: OutcomeCaptcha},
+		{Engine: "sogou", Status: OutcomeTimeout},
+	}
+	if got, want := summarizeOutcomes(outcomes), "baidu: captcha, sogou: timeout"; got != want {
+		t.Errorf("summarizeOutcomes() = %q, want %q", got, want)
+	}
+}
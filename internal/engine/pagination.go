@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// searchCursor 分页游标内容，对调用方不透明
+type searchCursor struct {
+	Offset int `json:"o"`
+	// Engines 各引擎已消费的结果数，多引擎合并截断后每个引擎的进度不同
+	Engines map[string]int `json:"e,omitempty"`
+}
+
+// EncodeCursor 将结果偏移量和各引擎的偏移编码为游标
+func EncodeCursor(offset int, engines map[string]int) string {
+	data, _ := json.Marshal(searchCursor{Offset: offset, Engines: engines})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor 解析游标，返回结果偏移量和各引擎的偏移（旧格式的游标没有后者）
+func DecodeCursor(cursor string) (int, map[string]int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid cursor: %s", cursor)
+	}
+
+	var c searchCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Offset < 0 {
+		return 0, nil, fmt.Errorf("invalid cursor: %s", cursor)
+	}
+	for _, offset := range c.Engines {
+		if offset < 0 {
+			return 0, nil, fmt.Errorf("invalid cursor: %s", cursor)
+		}
+	}
+	return c.Offset, c.Engines, nil
+}
+
+// nextEngineOffsets 计算下一页各引擎的起始偏移：每个引擎从被截掉的结果中排名最靠前的一条继续，
+// 这样合并后超出 limit 的结果留到下一页而不会丢失（之后的结果可能重复出现）。
+// 没有提供结果的引擎保持原偏移。返回是否还有更多结果
+func nextEngineOffsets(req SearchRequest, engines []string, pages map[string]engineSearch, cut []SearchResult) (map[string]int, bool) {
+	consumed := make(map[string]int, len(pages))
+	for name, page := range pages {
+		consumed[name] = page.fetched
+	}
+	for _, r := range cut {
+		for _, rank := range r.Engines {
+			page, ok := pages[rank.Engine]
+			if !ok || rank.Rank < 1 || rank.Rank > len(page.positions) {
+				continue
+			}
+			if pos := page.positions[rank.Rank-1]; pos < consumed[rank.Engine] {
+				consumed[rank.Engine] = pos
+			}
+		}
+	}
+
+	offsets := make(map[string]int, len(engines)+len(pages))
+	for _, name := range engines {
+		offsets[name] = req.engineOffset(name)
+	}
+	more := false
+	for name, page := range pages {
+		offsets[name] = req.engineOffset(name) + consumed[name]
+		if page.full || consumed[name] < page.fetched {
+			more = true
+		}
+	}
+	return offsets, more
+}
+
+// pageWindow 将结果偏移量换算为起始页序号（从 0 开始）和该页内需要跳过的条数
+func pageWindow(offset, pageSize int) (page, skip int) {
+	if offset <= 0 || pageSize <= 0 {
+		return 0, 0
+	}
+	return offset / pageSize, offset % pageSize
+}
+
+// window 跳过前 skip 条后截取最多 limit 条
+func window(results []SearchResult, skip, limit int) []SearchResult {
+	if skip >= len(results) {
+		return nil
+	}
+	results = results[skip:]
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
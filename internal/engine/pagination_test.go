@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := EncodeCursor(20, map[string]int{"bing": 12, "duckduckgo": 8})
+	offset, engines, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if offset != 20 || engines["bing"] != 12 || engines["duckduckgo"] != 8 {
+		t.Fatalf("got offset=%d engines=%v", offset, engines)
+	}
+
+	for _, bad := range []string{"!!!", EncodeCursor(-1, nil), EncodeCursor(0, map[string]int{"bing": -3})} {
+		if _, _, err := DecodeCursor(bad); err == nil {
+			t.Errorf("DecodeCursor(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestPageWindow(t *testing.T) {
+	tests := []struct {
+		offset, pageSize int
+		page, skip       int
+	}{
+		{0, 10, 0, 0},
+		{10, 10, 1, 0},
+		{25, 10, 2, 5},
+		{7, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		page, skip := pageWindow(tt.offset, tt.pageSize)
+		if page != tt.page || skip != tt.skip {
+			t.Errorf("pageWindow(%d, %d) = %d, %d, want %d, %d", tt.offset, tt.pageSize, page, skip, tt.page, tt.skip)
+		}
+	}
+}
+
+// 两个引擎合并后截断到 limit，按 next_cursor 翻页应能拿到两个引擎的全部结果
+func TestSearchPaginationAcrossEngines(t *testing.T) {
+	bing := newStubEngine("bing", 23)
+	ddg := newStubEngine("duckduckgo", 17)
+	m := newTestManager(t, bing, ddg)
+
+	seen := make(map[string]bool)
+	req := SearchRequest{Query: "paging", Limit: 10, Engines: []string{"bing", "duckduckgo"}}
+	for page := 0; ; page++ {
+		if page > 20 {
+			t.Fatal("pagination did not terminate")
+		}
+		resp, err := m.Search(context.Background(), req)
+		if err != nil {
+			t.Fatalf("page %d: %v", page, err)
+		}
+		if len(resp.Results) > 10 {
+			t.Fatalf("page %d returned %d results, want at most 10", page, len(resp.Results))
+		}
+		for _, r := range resp.Results {
+			seen[canonicalURL(r.URL)] = true
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+
+		offset, offsets, err := DecodeCursor(resp.NextCursor)
+		if err != nil {
+			t.Fatalf("page %d: %v", page, err)
+		}
+		req.Offset = offset
+		req.EngineOffsets = offsets
+	}
+
+	for _, e := range []*stubEngine{bing, ddg} {
+		for _, r := range e.results {
+			if !seen[canonicalURL(r.URL)] {
+				t.Errorf("result %s never returned", r.URL)
+			}
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 内置处理器名称
+const (
+	ProcessorNormalize = "normalize"
+	ProcessorFilter    = "filter"
+	ProcessorEnrich    = "enrich"
+	ProcessorDedupe    = "dedupe"
+	ProcessorRerank    = "rerank"
+)
+
+// Processor 结果处理器，Manager 在合并各引擎结果后按配置顺序依次执行
+// 自定义处理器通过 RegisterProcessor 注册，并在配置的 search.processors 中引用其名称
+type Processor interface {
+	Name() string
+	Process(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error)
+}
+
+// ProcessContext 处理器共享的上下文
+type ProcessContext struct {
+	Request SearchRequest
+	Query   *ParsedQuery
+	Rules   DomainRules
+
+	// Dropped 被处理器去掉的结果数（空结果、广告、搜索引擎结果页等），不含域名规则
+	Dropped int
+	// DroppedBy 各引擎被处理器去掉的结果数（合并后的结果来自多个引擎时分别计数）
+	DroppedBy map[string]int
+	// Filtered 被域名规则过滤掉的结果数
+	Filtered int
+	// FilteredBy 各引擎被域名规则过滤掉的结果数
+	FilteredBy map[string]int
+	// Warnings 需要提示调用方的警告
+	Warnings []string
+}
+
+// Drop 记录一条被处理器去掉的结果
+func (pc *ProcessContext) Drop(r SearchResult) {
+	pc.Dropped++
+	pc.DroppedBy = countByEngine(pc.DroppedBy, r)
+}
+
+// dropByDomainRule 记录一条被域名规则过滤的结果
+func (pc *ProcessContext) dropByDomainRule(r SearchResult) {
+	pc.Filtered++
+	pc.FilteredBy = countByEngine(pc.FilteredBy, r)
+}
+
+// countByEngine 为返回该结果的每个引擎计数一次
+func countByEngine(counts map[string]int, r SearchResult) map[string]int {
+	if counts == nil {
+		counts = make(map[string]int)
+	}
+	if len(r.Engines) == 0 {
+		counts[r.Engine]++
+		return counts
+	}
+	for _, rank := range r.Engines {
+		counts[rank.Engine]++
+	}
+	return counts
+}
+
+// processorFunc 用函数实现的处理器
+type processorFunc struct {
+	name string
+	fn   func(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error)
+}
+
+func (p processorFunc) Name() string { return p.name }
+
+func (p processorFunc) Process(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error) {
+	return p.fn(ctx, pc, results)
+}
+
+// NewProcessor 用函数创建处理器
+func NewProcessor(name string, fn func(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error)) Processor {
+	return processorFunc{name: name, fn: fn}
+}
+
+// processors 已注册的处理器
+var processors = struct {
+	sync.RWMutex
+	m map[string]Processor
+}{m: make(map[string]Processor)}
+
+// RegisterProcessor 注册处理器，同名处理器会被替换（可用于覆盖内置处理器）
+func RegisterProcessor(p Processor) {
+	processors.Lock()
+	defer processors.Unlock()
+	processors.m[p.Name()] = p
+}
+
+func init() {
+	RegisterProcessor(NewProcessor(ProcessorNormalize, normalizeResults))
+	RegisterProcessor(NewProcessor(ProcessorFilter, filterResults))
+	RegisterProcessor(NewProcessor(ProcessorEnrich, enrichResults))
+	RegisterProcessor(NewProcessor(ProcessorDedupe, dedupeResults))
+	RegisterProcessor(NewProcessor(ProcessorRerank, rerankResults))
+	RegisterProcessor(NewBM25Reranker(0.5, 0.5))
+}
+
+// Pipeline 按顺序执行的处理器列表
+type Pipeline struct {
+	processors []Processor
+}
+
+// NewPipeline 按名称创建处理流水线，未注册的名称会被忽略并返回。
+// overrides 只对本条流水线生效，优先于全局注册的同名处理器（例如按 Manager 配置的 BM25 权重）
+func NewPipeline(names []string, overrides ...Processor) (*Pipeline, []string) {
+	processors.RLock()
+	defer processors.RUnlock()
+
+	p := &Pipeline{}
+	var unknown []string
+	for _, name := range names {
+		proc, ok := processors.m[name]
+		for _, o := range overrides {
+			if o.Name() == name {
+				proc, ok = o, true
+			}
+		}
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		p.processors = append(p.processors, proc)
+	}
+	return p, unknown
+}
+
+// Names 返回处理器名称
+func (p *Pipeline) Names() []string {
+	names := make([]string, 0, len(p.processors))
+	for _, proc := range p.processors {
+		names = append(names, proc.Name())
+	}
+	return names
+}
+
+// Run 先按域名规则过滤，再依次执行处理器；某个处理器出错时跳过它并记录警告，继续使用之前的结果。
+// 域名规则不属于可配置的处理器，自定义的 processors 列表不会关掉它
+func (p *Pipeline) Run(ctx context.Context, pc *ProcessContext, results []SearchResult) []SearchResult {
+	results = applyDomainRules(pc, results)
+	for _, proc := range p.processors {
+		processed, err := proc.Process(ctx, pc, results)
+		if err != nil {
+			log.Printf("⚠️ Processor %s failed: %v", proc.Name(), err)
+			pc.Warnings = append(pc.Warnings, fmt.Sprintf("processor %s failed (%v); skipped", proc.Name(), err))
+			continue
+		}
+		results = processed
+	}
+	return results
+}
+
+// normalizeResults 清理标题和描述中的 HTML 标签（如 <em>）和多余空白，截断过长的描述
+func normalizeResults(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error) {
+	for i := range results {
+		r := &results[i]
+		r.URL = strings.TrimSpace(r.URL)
+		r.Title = cleanText(r.Title)
+		r.Description = truncateText(cleanText(r.Description), 500)
+	}
+	return results, nil
+}
+
+// adMarkers 标题开头或结尾表示广告的标记
+var adMarkers = []string{"广告", "推广"}
+
+// searchPagePatterns 指向搜索引擎自身结果页的链接
+var searchPagePatterns = []string{
+	"baidu.com/s?", "sogou.com/web", "sogou.com/tx?", "bing.com/search?", "google.com/search?", "duckduckgo.com/?q=",
+}
+
+// applyDomainRules 去掉被屏蔽或不在允许列表中的结果
+func applyDomainRules(pc *ProcessContext, results []SearchResult) []SearchResult {
+	if len(pc.Rules.Block) == 0 && len(pc.Rules.Allow) == 0 {
+		return results
+	}
+	kept := results[:0:0]
+	for _, r := range results {
+		if !pc.Rules.allows(r.URL) {
+			pc.dropByDomainRule(r)
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// filterResults 去掉缺少标题或链接的结果、广告和搜索引擎自身的结果页
+func filterResults(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error) {
+	kept := results[:0:0]
+	for _, r := range results {
+		if r.Title == "" || r.URL == "" || isAdOrSearchPage(r) {
+			pc.Drop(r)
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept, nil
+}
+
+// isAdOrSearchPage 判断是否为广告或搜索引擎自身的结果页
+func isAdOrSearchPage(r SearchResult) bool {
+	for _, marker := range adMarkers {
+		if strings.HasPrefix(r.Title, marker) || strings.HasSuffix(r.Title, marker) {
+			return true
+		}
+	}
+	for _, pattern := range searchPagePatterns {
+		if strings.Contains(r.URL, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// enrichResults 补全缺失的来源域名
+func enrichResults(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error) {
+	for i := range results {
+		if results[i].Source == "" {
+			results[i].Source = hostOf(results[i].URL)
+		}
+	}
+	return results, nil
+}
+
+// dedupeResults 去掉规范化 URL 相同的重复结果（例如规范化后才一致的链接），来源引擎合并到保留的结果上。
+// 同一站点标题相同的不同页面（文档、论坛帖子）不算重复
+func dedupeResults(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error) {
+	kept := results[:0:0]
+	index := make(map[string]int)
+
+	for _, r := range results {
+		key := canonicalURL(r.URL)
+		pos, dup := index[key]
+		if !dup {
+			index[key] = len(kept)
+			kept = append(kept, r)
+			continue
+		}
+
+		existing := &kept[pos]
+		for _, rank := range r.Engines {
+			if !hasEngine(existing.Engines, rank.Engine) {
+				existing.Engines = append(existing.Engines, rank)
+				existing.RRFScore += 1.0 / float64(rrfK+rank.Rank)
+			}
+		}
+		mergeFields(existing, r)
+	}
+	return kept, nil
+}
+
+// rerankResults 按域名权重调整融合得分并重新排序
+func rerankResults(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error) {
+	pc.Rules.ApplyBoosts(results)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].RRFScore > results[j].RRFScore
+	})
+	return results, nil
+}
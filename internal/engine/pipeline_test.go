@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/config"
+)
+
+// 每个 Manager 使用自己的 BM25 权重，创建第二个 Manager 不影响第一个
+func TestManagerBM25WeightsArePerManager(t *testing.T) {
+	newManager := func(bm25Weight float64) *Manager {
+		cfg := *config.DefaultConfig
+		cfg.Browser.Enabled = false
+		cfg.Search.BM25 = config.BM25Config{Enabled: true, BM25Weight: bm25Weight, RankWeight: 1 - bm25Weight}
+		return NewManager(&cfg)
+	}
+	bm25Of := func(m *Manager) *BM25Reranker {
+		for _, proc := range m.pipeline.processors {
+			if r, ok := proc.(*BM25Reranker); ok {
+				return r
+			}
+		}
+		t.Fatal("bm25 processor missing from pipeline")
+		return nil
+	}
+
+	first := newManager(0.2)
+	second := newManager(0.9)
+	if w := bm25Of(first).BM25Weight; w != 0.2 {
+		t.Errorf("first manager bm25 weight = %v, want 0.2", w)
+	}
+	if w := bm25Of(second).BM25Weight; w != 0.9 {
+		t.Errorf("second manager bm25 weight = %v, want 0.9", w)
+	}
+}
+
+func TestNewPipelineUnknownProcessors(t *testing.T) {
+	p, unknown := NewPipeline([]string{ProcessorNormalize, "missing", ProcessorDedupe})
+	if got := p.Names(); len(got) != 2 || got[0] != ProcessorNormalize || got[1] != ProcessorDedupe {
+		t.Errorf("Names() = %v", got)
+	}
+	if len(unknown) != 1 || unknown[0] != "missing" {
+		t.Errorf("unknown = %v, want [missing]", unknown)
+	}
+}
+
+func TestPipelineSkipsFailingProcessor(t *testing.T) {
+	failing := NewProcessor("failing", func(ctx context.Context, pc *ProcessContext, results []SearchResult) ([]SearchResult, error) {
+		return nil, context.DeadlineExceeded
+	})
+	p, _ := NewPipeline([]string{"failing", ProcessorNormalize}, failing)
+	pc := &ProcessContext{}
+	results := p.Run(context.Background(), pc, []SearchResult{{Title: " <em>Go</em>  docs ", URL: "https://go.dev"}})
+	if len(results) != 1 || results[0].Title != "Go docs" {
+		t.Fatalf("results = %+v", results)
+	}
+	if len(pc.Warnings) != 1 {
+		t.Errorf("warnings = %v, want one warning for the failing processor", pc.Warnings)
+	}
+}
+
+// 域名规则在自定义的处理器列表中没有 filter 时也生效
+func TestPipelineAppliesDomainRulesWithoutFilter(t *testing.T) {
+	p, _ := NewPipeline([]string{ProcessorNormalize, ProcessorDedupe})
+	pc := &ProcessContext{Rules: DomainRules{Block: []string{"csdn.net"}}}
+	results := p.Run(context.Background(), pc, []SearchResult{
+		{Title: "a", URL: "https://blog.csdn.net/a", Engine: "baidu"},
+		{Title: "b", URL: "https://go.dev/b", Engine: "baidu"},
+	})
+	if len(results) != 1 || results[0].URL != "https://go.dev/b" {
+		t.Fatalf("results = %+v", results)
+	}
+	if pc.Filtered != 1 || pc.FilteredBy["baidu"] != 1 || pc.Dropped != 0 {
+		t.Errorf("filtered = %d, by = %v, dropped = %d", pc.Filtered, pc.FilteredBy, pc.Dropped)
+	}
+}
+
+func TestDedupeResults(t *testing.T) {
+	results := []SearchResult{
+		{Title: "Getting Started", URL: "https://docs.example.com/go/start", Engines: []EngineRank{{Engine: "bing", Rank: 1}}},
+		// 同一站点、标题相同的不同页面
+		{Title: "Getting Started", URL: "https://docs.example.com/rust/start", Engines: []EngineRank{{Engine: "bing", Rank: 2}}},
+		// 规范化后与第一条相同
+		{Title: "Getting started (mirror)", URL: "http://www.docs.example.com/go/start/?utm_source=x", Engines: []EngineRank{{Engine: "duckduckgo", Rank: 3}}},
+	}
+
+	kept, err := dedupeResults(context.Background(), &ProcessContext{}, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kept) != 2 {
+		t.Fatalf("kept %d results, want 2: %+v", len(kept), kept)
+	}
+	if len(kept[0].Engines) != 2 || kept[0].Engines[1].Engine != "duckduckgo" {
+		t.Errorf("engines not merged into kept result: %+v", kept[0].Engines)
+	}
+}
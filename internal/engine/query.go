@@ -0,0 +1,395 @@
+package engine
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// 查询操作符名称，用于提示和后置过滤
+const (
+	OperatorSite        = "site"
+	OperatorExcludeSite = "-site"
+	OperatorFileType    = "filetype"
+	OperatorInTitle     = "intitle"
+	OperatorPhrase      = "phrase"
+	OperatorExclude     = "-term"
+	OperatorOR          = "OR"
+)
+
+// QueryDialect 描述引擎原生支持的查询操作符，不支持的操作符由管理器做后置过滤
+type QueryDialect struct {
+	Site        bool
+	ExcludeSite bool
+	FileType    bool
+	InTitle     bool
+	Phrase      bool
+	Exclude     bool
+	// OR 引擎使用的“或”关键字（OR 或 |），为空表示不支持
+	OR string
+}
+
+// standardDialect Bing、Google、DuckDuckGo 通用的完整操作符语法
+var standardDialect = QueryDialect{
+	Site:        true,
+	ExcludeSite: true,
+	FileType:    true,
+	InTitle:     true,
+	Phrase:      true,
+	Exclude:     true,
+	OR:          "OR",
+}
+
+// QueryTerm 查询中的一个词或短语
+type QueryTerm struct {
+	Text   string
+	Phrase bool
+}
+
+// ParsedQuery 解析后的查询
+type ParsedQuery struct {
+	Raw string
+	// Groups 之间为 AND 关系，组内为 OR 关系
+	Groups        [][]QueryTerm
+	Excluded      []QueryTerm
+	Sites         []string
+	ExcludedSites []string
+	FileTypes     []string
+	InTitle       []QueryTerm
+}
+
+// ParseQuery 解析通用操作符语法：site:、-site:、filetype:/ext:、intitle:、"精确短语"、-排除词、OR/|
+func ParseQuery(raw string) *ParsedQuery {
+	q := &ParsedQuery{Raw: raw}
+	orNext := false
+
+	for _, tok := range tokenizeQuery(raw) {
+		if !tok.quoted && (tok.text == "OR" || tok.text == "|") {
+			orNext = len(q.Groups) > 0
+			continue
+		}
+
+		text := tok.text
+		negated := false
+		if strings.HasPrefix(text, "-") && len(text) > 1 {
+			negated = true
+			text = text[1:]
+		}
+
+		if !tok.quoted {
+			if name, value, ok := splitOperator(text); ok {
+				switch name {
+				case "site":
+					site := normalizeSite(value)
+					if negated {
+						q.ExcludedSites = append(q.ExcludedSites, site)
+					} else {
+						q.Sites = append(q.Sites, site)
+					}
+				case "filetype", "ext":
+					q.FileTypes = append(q.FileTypes, strings.ToLower(strings.TrimPrefix(value, ".")))
+				case "intitle":
+					term := QueryTerm{Text: unquote(value), Phrase: strings.ContainsAny(unquote(value), " \t")}
+					if negated {
+						q.Excluded = append(q.Excluded, term)
+					} else {
+						q.InTitle = append(q.InTitle, term)
+					}
+				}
+				orNext = false
+				continue
+			}
+		}
+
+		term := QueryTerm{Text: unquote(text), Phrase: tok.quoted}
+		if term.Text == "" {
+			continue
+		}
+
+		switch {
+		case negated:
+			q.Excluded = append(q.Excluded, term)
+		case orNext:
+			last := len(q.Groups) - 1
+			q.Groups[last] = append(q.Groups[last], term)
+		default:
+			q.Groups = append(q.Groups, []QueryTerm{term})
+		}
+		orNext = false
+	}
+
+	return q
+}
+
+// HasOperators 判断查询是否使用了任何操作符
+func (q *ParsedQuery) HasOperators() bool {
+	if len(q.Excluded) > 0 || len(q.Sites) > 0 || len(q.ExcludedSites) > 0 ||
+		len(q.FileTypes) > 0 || len(q.InTitle) > 0 {
+		return true
+	}
+	for _, group := range q.Groups {
+		if len(group) > 1 {
+			return true
+		}
+		for _, t := range group {
+			if t.Phrase {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Translate 将查询转换为引擎方言，返回查询字符串和需要后置过滤（或被忽略）的操作符
+func (q *ParsedQuery) Translate(d QueryDialect) (string, []string) {
+	if !q.HasOperators() {
+		return strings.TrimSpace(q.Raw), nil
+	}
+
+	var parts, unsupported []string
+	usedPhrase, usedOR := false, false
+
+	renderTerm := func(t QueryTerm) string {
+		if t.Phrase {
+			usedPhrase = true
+			if d.Phrase {
+				return `"` + t.Text + `"`
+			}
+		}
+		return t.Text
+	}
+
+	for _, group := range q.Groups {
+		alts := make([]string, 0, len(group))
+		for _, t := range group {
+			alts = append(alts, renderTerm(t))
+		}
+		if len(alts) > 1 {
+			usedOR = true
+			if d.OR != "" {
+				parts = append(parts, strings.Join(alts, " "+d.OR+" "))
+				continue
+			}
+		}
+		parts = append(parts, alts...)
+	}
+
+	// 不支持 intitle 时仍保留关键词参与检索，标题匹配由后置过滤保证
+	for _, t := range q.InTitle {
+		if d.InTitle {
+			parts = append(parts, "intitle:"+quoteIfNeeded(t))
+		} else {
+			parts = append(parts, renderTerm(t))
+		}
+	}
+	if len(q.InTitle) > 0 && !d.InTitle {
+		unsupported = append(unsupported, OperatorInTitle)
+	}
+
+	for _, t := range q.Excluded {
+		if d.Exclude {
+			parts = append(parts, "-"+quoteIfNeeded(t))
+		}
+	}
+	if len(q.Excluded) > 0 && !d.Exclude {
+		unsupported = append(unsupported, OperatorExclude)
+	}
+
+	// 多个 site 之间为 OR 关系，引擎不支持 OR 时只能后置过滤
+	if len(q.Sites) > 0 {
+		if d.Site && (len(q.Sites) == 1 || d.OR != "") {
+			sites := make([]string, 0, len(q.Sites))
+			for _, s := range q.Sites {
+				sites = append(sites, "site:"+s)
+			}
+			parts = append(parts, strings.Join(sites, " "+d.OR+" "))
+		} else {
+			unsupported = append(unsupported, OperatorSite)
+		}
+	}
+
+	for _, s := range q.ExcludedSites {
+		if d.ExcludeSite {
+			parts = append(parts, "-site:"+s)
+		}
+	}
+	if len(q.ExcludedSites) > 0 && !d.ExcludeSite {
+		unsupported = append(unsupported, OperatorExcludeSite)
+	}
+
+	if len(q.FileTypes) > 0 {
+		if d.FileType && (len(q.FileTypes) == 1 || d.OR != "") {
+			types := make([]string, 0, len(q.FileTypes))
+			for _, t := range q.FileTypes {
+				types = append(types, "filetype:"+t)
+			}
+			parts = append(parts, strings.Join(types, " "+d.OR+" "))
+		} else {
+			unsupported = append(unsupported, OperatorFileType)
+		}
+	}
+
+	if usedPhrase && !d.Phrase {
+		unsupported = append(unsupported, OperatorPhrase)
+	}
+	if usedOR && d.OR == "" {
+		unsupported = append(unsupported, OperatorOR)
+	}
+
+	return strings.Join(parts, " "), unsupported
+}
+
+// matches 检查单条结果是否满足引擎未能处理的操作符；OR 无法可靠地后置过滤，不参与检查
+func (q *ParsedQuery) matches(r SearchResult, operators []string) bool {
+	title := strings.ToLower(r.Title)
+	text := title + " " + strings.ToLower(r.Description)
+
+	u, _ := url.Parse(r.URL)
+
+	for _, op := range operators {
+		switch op {
+		case OperatorSite:
+			if u == nil || !matchesAnySite(u, q.Sites) {
+				return false
+			}
+		case OperatorExcludeSite:
+			if u != nil && matchesAnySite(u, q.ExcludedSites) {
+				return false
+			}
+		case OperatorFileType:
+			if u == nil || !matchesAnyFileType(u.Path, q.FileTypes) {
+				return false
+			}
+		case OperatorInTitle:
+			for _, t := range q.InTitle {
+				if !containsFold(title, t.Text) {
+					return false
+				}
+			}
+		case OperatorPhrase:
+			for _, group := range q.Groups {
+				// 仅对单独出现的短语做要求，OR 组中的短语无法确定
+				if len(group) == 1 && group[0].Phrase && !containsFold(text, group[0].Text) {
+					return false
+				}
+			}
+		case OperatorExclude:
+			for _, t := range q.Excluded {
+				if containsFold(text, t.Text) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// queryToken 分词结果
+type queryToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeQuery 按空白分词，引号（含中文引号）内的内容作为整体
+func tokenizeQuery(raw string) []queryToken {
+	var tokens []queryToken
+	var sb strings.Builder
+	quoted, inQuote := false, false
+
+	flush := func() {
+		if sb.Len() > 0 {
+			tokens = append(tokens, queryToken{text: sb.String(), quoted: quoted})
+		}
+		sb.Reset()
+		quoted = false
+	}
+
+	for _, r := range raw {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			if !inQuote {
+				// 只有以引号开头（可带 - 前缀）的词才视为短语，intitle:"..." 等仍按操作符处理
+				prefix := sb.String()
+				quoted = prefix == "" || prefix == "-"
+			}
+			inQuote = !inQuote
+			sb.WriteRune('"')
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	flush()
+
+	for i := range tokens {
+		if tokens[i].quoted {
+			tokens[i].text = strings.ReplaceAll(tokens[i].text, `"`, "")
+		}
+	}
+	return tokens
+}
+
+// splitOperator 拆分 name:value 形式的操作符
+func splitOperator(text string) (name, value string, ok bool) {
+	idx := strings.Index(text, ":")
+	if idx <= 0 || idx == len(text)-1 {
+		return "", "", false
+	}
+	name = strings.ToLower(text[:idx])
+	switch name {
+	case "site", "filetype", "ext", "intitle":
+		return name, text[idx+1:], true
+	}
+	return "", "", false
+}
+
+// normalizeSite 去掉 site 值中的协议和末尾斜杠
+func normalizeSite(site string) string {
+	site = strings.ToLower(unquote(site))
+	site = strings.TrimPrefix(site, "https://")
+	site = strings.TrimPrefix(site, "http://")
+	return strings.TrimSuffix(site, "/")
+}
+
+func unquote(s string) string {
+	return strings.TrimSpace(strings.Trim(s, `"`))
+}
+
+func quoteIfNeeded(t QueryTerm) string {
+	if t.Phrase || strings.ContainsAny(t.Text, " \t") {
+		return `"` + t.Text + `"`
+	}
+	return t.Text
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(s, strings.ToLower(substr))
+}
+
+// matchesAnySite 检查 URL 是否属于任一站点（域名后缀匹配，可带路径前缀）
+func matchesAnySite(u *url.URL, sites []string) bool {
+	host := strings.ToLower(strings.TrimPrefix(u.Hostname(), "www."))
+	for _, site := range sites {
+		siteHost, sitePath, _ := strings.Cut(site, "/")
+		siteHost = strings.TrimPrefix(siteHost, "www.")
+		if host != siteHost && !strings.HasSuffix(host, "."+siteHost) {
+			continue
+		}
+		if sitePath == "" || strings.HasPrefix(strings.TrimPrefix(u.Path, "/"), sitePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyFileType 检查 URL 路径的扩展名
+func matchesAnyFileType(path string, types []string) bool {
+	path = strings.ToLower(path)
+	for _, t := range types {
+		if strings.HasSuffix(path, "."+t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	q := ParseQuery(`golang site:https://go.dev/ -site:pkg.go.dev ext:.PDF intitle:"release notes" "generic types" -beta tutorial OR guide`)
+
+	wantGroups := [][]QueryTerm{
+		{{Text: "golang"}},
+		{{Text: "generic types", Phrase: true}},
+		{{Text: "tutorial"}, {Text: "guide"}},
+	}
+	if !reflect.DeepEqual(q.Groups, wantGroups) {
+		t.Errorf("Groups = %+v, want %+v", q.Groups, wantGroups)
+	}
+	if !reflect.DeepEqual(q.Sites, []string{"go.dev"}) {
+		t.Errorf("Sites = %v", q.Sites)
+	}
+	if !reflect.DeepEqual(q.ExcludedSites, []string{"pkg.go.dev"}) {
+		t.Errorf("ExcludedSites = %v", q.ExcludedSites)
+	}
+	if !reflect.DeepEqual(q.FileTypes, []string{"pdf"}) {
+		t.Errorf("FileTypes = %v", q.FileTypes)
+	}
+	if !reflect.DeepEqual(q.InTitle, []QueryTerm{{Text: "release notes", Phrase: true}}) {
+		t.Errorf("InTitle = %+v", q.InTitle)
+	}
+	if !reflect.DeepEqual(q.Excluded, []QueryTerm{{Text: "beta"}}) {
+		t.Errorf("Excluded = %+v", q.Excluded)
+	}
+}
+
+func TestParseQueryEdgeCases(t *testing.T) {
+	tests := []struct {
+		raw    string
+		groups [][]QueryTerm
+		ops    bool
+	}{
+		// 开头的 OR 没有左操作数，忽略
+		{"OR golang", [][]QueryTerm{{{Text: "golang"}}}, false},
+		{"a | b", [][]QueryTerm{{{Text: "a"}, {Text: "b"}}}, true},
+		// 中文引号
+		{"“并发 编程”", [][]QueryTerm{{{Text: "并发 编程", Phrase: true}}}, true},
+		// 单独的 - 和未知前缀按普通词处理
+		{"- c++ http://x", [][]QueryTerm{{{Text: "-"}}, {{Text: "c++"}}, {{Text: "http://x"}}}, false},
+	}
+	for _, tt := range tests {
+		q := ParseQuery(tt.raw)
+		if !reflect.DeepEqual(q.Groups, tt.groups) {
+			t.Errorf("ParseQuery(%q).Groups = %+v, want %+v", tt.raw, q.Groups, tt.groups)
+		}
+		if got := q.HasOperators(); got != tt.ops {
+			t.Errorf("ParseQuery(%q).HasOperators() = %v, want %v", tt.raw, got, tt.ops)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		dialect     QueryDialect
+		query       string
+		unsupported []string
+	}{
+		{
+			name:    "plain query passes through",
+			raw:     "  golang generics ",
+			dialect: QueryDialect{},
+			query:   "golang generics",
+		},
+		{
+			name:    "standard dialect keeps everything",
+			raw:     `"go vet" -beta site:go.dev filetype:pdf a OR b`,
+			dialect: standardDialect,
+			query:   `"go vet" a OR b -beta site:go.dev filetype:pdf`,
+		},
+		{
+			name:    "pipe OR",
+			raw:     "a OR b site:x.com site:y.com",
+			dialect: QueryDialect{Site: true, OR: "|"},
+			query:   "a | b site:x.com | site:y.com",
+		},
+		{
+			name:        "multiple sites without OR fall back",
+			raw:         "golang site:x.com site:y.com",
+			dialect:     QueryDialect{Site: true, FileType: true, InTitle: true, Phrase: true, Exclude: true},
+			query:       "golang",
+			unsupported: []string{OperatorSite},
+		},
+		{
+			name:        "bare dialect keeps keywords and reports operators",
+			raw:         `"go vet" -beta intitle:release -site:x.com filetype:pdf a OR b`,
+			dialect:     QueryDialect{},
+			query:       "go vet a b release",
+			unsupported: []string{OperatorInTitle, OperatorExclude, OperatorExcludeSite, OperatorFileType, OperatorPhrase, OperatorOR},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, unsupported := ParseQuery(tt.raw).Translate(tt.dialect)
+			if query != tt.query {
+				t.Errorf("query = %q, want %q", query, tt.query)
+			}
+			if !reflect.DeepEqual(unsupported, tt.unsupported) {
+				t.Errorf("unsupported = %v, want %v", unsupported, tt.unsupported)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	q := ParseQuery(`"go vet" -beta intitle:release site:go.dev/doc -site:tip.go.dev filetype:pdf`)
+	tests := []struct {
+		result SearchResult
+		want   bool
+	}{
+		{SearchResult{Title: "Release notes", Description: "run go vet", URL: "https://www.go.dev/doc/notes.pdf"}, true},
+		{SearchResult{Title: "Release notes", Description: "run go vet", URL: "https://go.dev/blog/notes.pdf"}, false},
+		{SearchResult{Title: "Release notes", Description: "run go vet", URL: "https://tip.go.dev/doc/notes.pdf"}, false},
+		{SearchResult{Title: "Release notes", Description: "run go vet", URL: "https://go.dev/doc/notes.html"}, false},
+		{SearchResult{Title: "Notes", Description: "run go vet", URL: "https://go.dev/doc/notes.pdf"}, false},
+		{SearchResult{Title: "Release notes", Description: "run vet", URL: "https://go.dev/doc/notes.pdf"}, false},
+		{SearchResult{Title: "Release notes beta", Description: "run go vet", URL: "https://go.dev/doc/notes.pdf"}, false},
+	}
+	ops := []string{OperatorSite, OperatorExcludeSite, OperatorFileType, OperatorInTitle, OperatorPhrase, OperatorExclude}
+
+	for _, tt := range tests {
+		if got := q.matches(tt.result, ops); got != tt.want {
+			t.Errorf("matches(%s, %q) = %v, want %v", tt.result.URL, tt.result.Title, got, tt.want)
+		}
+		// 没有需要后置处理的操作符时不过滤任何结果
+		if !q.matches(tt.result, nil) {
+			t.Errorf("matches(%s) with no operators = false", tt.result.URL)
+		}
+	}
+}
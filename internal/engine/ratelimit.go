@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/config"
+)
+
+// RateLimiter 令牌桶限流器，按固定速率补充令牌，最多积累 burst 个
+type RateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter 创建限流器，rate 为每秒请求数
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait 等待获取一个令牌；令牌不足时预占并等待补充，ctx 取消时归还预占的令牌。nil 表示不限流，立即返回
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// newRateLimiters 按配置为每个引擎创建限流器，同一引擎的所有并发请求（包括翻页和预热请求）在同一个令牌桶上等待；
+// requests_per_second 为 0 的引擎不限流
+func newRateLimiters(cfg *config.Config) map[string]*RateLimiter {
+	limiters := make(map[string]*RateLimiter)
+	for _, name := range config.ValidEngines {
+		limit := cfg.GetRateLimit(name)
+		if limit.RequestsPerSecond > 0 {
+			limiters[name] = NewRateLimiter(limit.RequestsPerSecond, limit.Burst)
+		}
+	}
+	return limiters
+}
+
+// rateLimitedTransport 发送请求前先等待引擎的限流令牌
+type rateLimitedTransport struct {
+	limiter *RateLimiter
+	// limiters/engineOf 按请求地址所属的引擎选择限流器，设置时优先于 limiter
+	limiters map[string]*RateLimiter
+	engineOf func(*url.URL) string
+	base     http.RoundTripper
+}
+
+// newRateLimitedTransport 为引擎的 HTTP 客户端包装限流，limiter 为 nil 时不限流
+func newRateLimitedTransport(limiter *RateLimiter, base http.RoundTripper) http.RoundTripper {
+	return &rateLimitedTransport{limiter: limiter, base: base}
+}
+
+// newHostRateLimitedTransport 按请求地址所属的引擎限流，用于会访问多个引擎站点的客户端；engineOf 返回空字符串时不限流
+func newHostRateLimitedTransport(limiters map[string]*RateLimiter, engineOf func(*url.URL) string, base http.RoundTripper) http.RoundTripper {
+	return &rateLimitedTransport{limiters: limiters, engineOf: engineOf, base: base}
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := t.limiter
+	if t.engineOf != nil {
+		limiter = t.limiters[t.engineOf(req.URL)]
+	}
+	if err := limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/config"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := NewRateLimiter(10, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait() = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("burst of 3 took %v, want no wait", elapsed)
+	}
+
+	// 令牌用尽后按 10/s 补充，第 4 个请求约等待 100ms
+	start = time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("fourth request waited %v, want about 100ms", elapsed)
+	}
+}
+
+func TestRateLimiterMinimumBurst(t *testing.T) {
+	l := NewRateLimiter(1, 0)
+	if l.burst != 1 || l.tokens != 1 {
+		t.Errorf("burst = %v, tokens = %v; want 1", l.burst, l.tokens)
+	}
+}
+
+func TestRateLimiterCancelReturnsToken(t *testing.T) {
+	l := NewRateLimiter(10, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() with canceled ctx = %v, want context.Canceled", err)
+	}
+
+	// 取消的请求归还了预占的令牌，下一个请求只需等待一个令牌的补充时间
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Wait() after cancel took %v, want about 100ms", elapsed)
+	}
+}
+
+type countingTransport struct {
+	calls int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestHostRateLimitedTransport(t *testing.T) {
+	limiters := map[string]*RateLimiter{"bing": NewRateLimiter(0.001, 1)}
+	base := &countingTransport{}
+	transport := newHostRateLimitedTransport(limiters, trackingLinkEngine, base)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	do := func(link string) error {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+		_, err := transport.RoundTrip(req)
+		return err
+	}
+
+	if err := do("https://www.bing.com/ck/a?u=1"); err != nil {
+		t.Fatalf("first bing request = %v", err)
+	}
+	// bing 的令牌已用尽，需要等待；其他站点不受限
+	if err := do("https://www.bing.com/ck/a?u=2"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second bing request = %v, want context.DeadlineExceeded", err)
+	}
+	if err := do("https://go.dev/"); err != nil {
+		t.Errorf("unlimited host request = %v", err)
+	}
+	if base.calls != 2 {
+		t.Errorf("base transport calls = %d, want 2", base.calls)
+	}
+}
+
+func TestNilRateLimiterDoesNotWait(t *testing.T) {
+	var l *RateLimiter
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() = %v", err)
+		}
+	}
+}
+
+func TestManagersKeepSeparateRateLimiters(t *testing.T) {
+	a := newTestManager(t)
+	b := newTestManagerWith(t, func(cfg *config.Config) {
+		cfg.Search.RateLimits = map[string]config.RateLimitConfig{"baidu": {RequestsPerSecond: 0}}
+	})
+
+	if a.limiters["baidu"] == nil {
+		t.Fatal("default config should rate-limit baidu")
+	}
+	if b.limiters["baidu"] != nil {
+		t.Error("requests_per_second 0 should disable the baidu limiter")
+	}
+	// 创建第二个 Manager 不替换第一个 Manager 的限流器
+	if a.limiters["sogou"] == b.limiters["sogou"] {
+		t.Error("managers share the sogou limiter")
+	}
+}
@@ -0,0 +1,230 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RedditEngine Reddit 讨论搜索引擎实现（基于 search.json）
+type RedditEngine struct {
+	client   *http.Client
+	proxyURL string
+}
+
+// NewRedditEngine 创建 Reddit 搜索引擎实例
+func NewRedditEngine(proxyURL string, limiter *RateLimiter) *RedditEngine {
+	transport := &http.Transport{}
+	if proxyURL != "" {
+		if proxy, err := url.Parse(proxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+
+	client := &http.Client{
+		Transport: newRateLimitedTransport(limiter, transport),
+	}
+
+	return &RedditEngine{
+		client:   client,
+		proxyURL: proxyURL,
+	}
+}
+
+// Name 返回引擎名称
+func (e *RedditEngine) Name() string {
+	return "reddit"
+}
+
+// Capabilities 返回引擎能力描述
+func (e *RedditEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryDiscussion,
+		Description: "Reddit posts",
+		Verticals:   webOnly,
+		Filters:     []string{FilterFreshness, FilterSort},
+		MaxResults:  redditMaxDepth,
+		Pagination:  true,
+		RegionFocus: RegionGlobal,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符（site: 匹配链接帖的域名）
+func (e *RedditEngine) QueryDialect() QueryDialect {
+	return QueryDialect{Site: true, Phrase: true, OR: "OR"}
+}
+
+// redditListing Reddit 列表响应
+type redditListing struct {
+	Data struct {
+		Children []struct {
+			Data redditPost `json:"data"`
+		} `json:"children"`
+		// After 下一页的游标，没有更多结果时为空
+		After string `json:"after"`
+	} `json:"data"`
+}
+
+// redditPost 单条帖子
+type redditPost struct {
+	Title       string  `json:"title"`
+	URL         string  `json:"url"`
+	Permalink   string  `json:"permalink"`
+	Subreddit   string  `json:"subreddit_name_prefixed"`
+	Selftext    string  `json:"selftext"`
+	IsSelf      bool    `json:"is_self"`
+	Score       int     `json:"score"`
+	NumComments int     `json:"num_comments"`
+	CreatedUTC  float64 `json:"created_utc"`
+	Over18      bool    `json:"over_18"`
+}
+
+// Reddit 列表接口单次最多返回 100 条，通过 after 游标最多能翻到约 1000 条
+const (
+	redditPageSize = 100
+	redditMaxDepth = 1000
+)
+
+// Search 执行 Reddit 搜索
+func (e *RedditEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	// 接口只能通过 after 游标翻页，这里逐页取回 offset+limit 条再跳过前 offset 条
+	want := opts.Offset + limit
+	if want > redditMaxDepth {
+		want = redditMaxDepth
+	}
+
+	var allResults []SearchResult
+	after := ""
+	count := 0
+	for page := 0; len(allResults) < want && page < redditMaxDepth/redditPageSize; page++ {
+		results, next, n, err := e.searchPage(ctx, query, min(redditPageSize, want-len(allResults)), after, count, opts)
+		if err != nil {
+			if len(allResults) > 0 {
+				// 如果已经有一些结果，就返回这些
+				break
+			}
+			return nil, err
+		}
+
+		allResults = append(allResults, results...)
+		count += n
+		after = next
+		if n == 0 || after == "" {
+			break
+		}
+	}
+
+	results := window(allResults, opts.Offset, limit)
+
+	log.Printf("🔍 Reddit: found %d results for query '%s'", len(results), query)
+	return results, nil
+}
+
+// searchPage 获取一页帖子，返回解析后的结果、下一页的 after 游标和本页原始帖子数；count 为之前已取回的帖子数
+func (e *RedditEngine) searchPage(ctx context.Context, query string, size int, after string, count int, opts SearchOptions) ([]SearchResult, string, int, error) {
+	sort := "relevance"
+	if opts.Sort == SortDate {
+		sort = "new"
+	}
+
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("sort", sort)
+	params.Set("limit", fmt.Sprintf("%d", size))
+	params.Set("type", "link")
+	params.Set("raw_json", "1")
+	if after != "" {
+		params.Set("after", after)
+		params.Set("count", fmt.Sprintf("%d", count))
+	}
+	switch opts.Freshness {
+	case FreshnessDay, FreshnessWeek, FreshnessMonth, FreshnessYear:
+		params.Set("t", map[string]string{
+			FreshnessDay:   "day",
+			FreshnessWeek:  "week",
+			FreshnessMonth: "month",
+			FreshnessYear:  "year",
+		}[opts.Freshness])
+	}
+
+	searchURL := fmt.Sprintf("https://www.reddit.com/search.json?%s", params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("create request failed: %w", err)
+	}
+	// Reddit 会拒绝默认的 Go User-Agent
+	req.Header.Set("User-Agent", "go-web-search-mcp/1.0 (by /u/go-web-search-mcp)")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, "", 0, fmt.Errorf("reddit rate limited: too many requests")
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", 0, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	var listing redditListing
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return nil, "", 0, fmt.Errorf("parse JSON failed: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(listing.Data.Children))
+	for _, child := range listing.Data.Children {
+		if result := e.parsePost(child.Data); result != nil {
+			results = append(results, *result)
+		}
+	}
+	return results, listing.Data.After, len(listing.Data.Children), nil
+}
+
+// parsePost 解析单条帖子
+func (e *RedditEngine) parsePost(post redditPost) *SearchResult {
+	title := strings.TrimSpace(post.Title)
+	if title == "" || post.Permalink == "" {
+		return nil
+	}
+
+	// 过滤 NSFW 内容
+	if post.Over18 {
+		return nil
+	}
+
+	discussionURL := "https://www.reddit.com" + post.Permalink
+
+	// 自发帖没有外链，链接即讨论页
+	href := post.URL
+	if post.IsSelf || href == "" {
+		href = discussionURL
+	}
+
+	publishedAt := ""
+	if post.CreatedUTC > 0 {
+		publishedAt = time.Unix(int64(post.CreatedUTC), 0).UTC().Format(time.RFC3339)
+	}
+
+	return &SearchResult{
+		Title:         title,
+		URL:           href,
+		Description:   truncateText(cleanText(post.Selftext), 500),
+		Source:        post.Subreddit,
+		Engine:        "reddit",
+		Points:        post.Score,
+		CommentCount:  post.NumComments,
+		DiscussionURL: discussionURL,
+		PublishedAt:   publishedAt,
+	}
+}
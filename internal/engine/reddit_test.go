@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// redditStubTransport 按 after 游标返回共 total 条帖子的列表
+type redditStubTransport struct {
+	total    int
+	requests int
+}
+
+func (t *redditStubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	q := req.URL.Query()
+	start := 0
+	if after := q.Get("after"); after != "" {
+		start, _ = strconv.Atoi(strings.TrimPrefix(after, "t3_"))
+	}
+	size, _ := strconv.Atoi(q.Get("limit"))
+
+	var listing redditListing
+	for i := start; i < start+size && i < t.total; i++ {
+		var child struct {
+			Data redditPost `json:"data"`
+		}
+		child.Data = redditPost{Title: fmt.Sprintf("post %d", i), Permalink: fmt.Sprintf("/r/golang/comments/%d/", i), IsSelf: true}
+		listing.Data.Children = append(listing.Data.Children, child)
+	}
+	if end := start + len(listing.Data.Children); end < t.total {
+		listing.Data.After = fmt.Sprintf("t3_%d", end)
+	}
+
+	body, _ := json.Marshal(listing)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(string(body))),
+		Request:    req,
+	}, nil
+}
+
+// offset 超过单页上限 100 时通过 after 游标继续翻页
+func TestRedditSearchPagesWithAfter(t *testing.T) {
+	tests := []struct {
+		offset, limit int
+		first, count  int
+	}{
+		{0, 10, 0, 10},
+		{95, 10, 95, 10},
+		{150, 10, 150, 10},
+		{245, 10, 245, 5},
+		{300, 10, 0, 0},
+	}
+	for _, tt := range tests {
+		transport := &redditStubTransport{total: 250}
+		e := &RedditEngine{client: &http.Client{Transport: transport}}
+
+		results, err := e.Search(context.Background(), "golang", tt.limit, SearchOptions{Offset: tt.offset})
+		if err != nil {
+			t.Fatalf("offset %d: %v", tt.offset, err)
+		}
+		if len(results) != tt.count {
+			t.Fatalf("offset %d: got %d results, want %d", tt.offset, len(results), tt.count)
+		}
+		if tt.count > 0 && results[0].Title != fmt.Sprintf("post %d", tt.first) {
+			t.Errorf("offset %d: first result %q, want post %d", tt.offset, results[0].Title, tt.first)
+		}
+	}
+}
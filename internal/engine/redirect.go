@@ -0,0 +1,273 @@
+package engine
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// redirectConcurrency 同时进行的跳转解析请求数
+	redirectConcurrency = 8
+	// redirectCacheSize 跳转解析缓存的最大条目数
+	redirectCacheSize = 2048
+	// redirectTimeout 单个链接的解析超时
+	redirectTimeout = 5 * time.Second
+)
+
+// redirectTargetPatterns 跳转页中常见的目标地址写法
+var redirectTargetPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`window\.location\.replace\(["']([^"']+)["']\)`),
+	regexp.MustCompile(`(?i)http-equiv=["']?refresh["']?[^>]*url=['"]?([^'" >]+)`),
+	regexp.MustCompile(`location\.href\s*=\s*["']([^"']+)["']`),
+}
+
+// RedirectResolver 将搜索引擎的跟踪跳转链接解析为真实地址
+type RedirectResolver struct {
+	client *http.Client
+	sem    chan struct{}
+
+	mu    sync.Mutex
+	cache map[string]string
+	order []string
+}
+
+// NewRedirectResolver 创建跳转链接解析器，limiters 为解析请求按引擎使用的限流器
+func NewRedirectResolver(proxyURL string, limiters map[string]*RateLimiter) *RedirectResolver {
+	transport := &http.Transport{}
+	if proxyURL != "" {
+		if proxy, err := url.Parse(proxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+
+	client := &http.Client{
+		Timeout: redirectTimeout,
+		// 跳转链接指向搜索引擎自己的站点，按所属引擎限流，避免解析一页结果时突发大量请求
+		Transport: newHostRateLimitedTransport(limiters, trackingLinkEngine, transport),
+		// 不跟随跳转，直接读取 Location
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return &RedirectResolver{
+		client: client,
+		sem:    make(chan struct{}, redirectConcurrency),
+		cache:  make(map[string]string),
+	}
+}
+
+// isTrackingLink 判断是否为需要解析的跳转链接（百度 link?url=、搜狗 /link?、Bing ck/a）
+func isTrackingLink(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return trackingLinkEngine(u) != ""
+}
+
+// trackingLinkEngine 返回跳转链接所属的引擎，不是跳转链接时返回空字符串
+func trackingLinkEngine(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case (host == "www.baidu.com" || host == "m.baidu.com" || host == "baidu.com") && u.Path == "/link":
+		return "baidu"
+	case (host == "www.sogou.com" || host == "wap.sogou.com" || host == "m.sogou.com" || host == "sogou.com") && u.Path == "/link":
+		return "sogou"
+	case strings.HasSuffix(host, "bing.com") && u.Path == "/ck/a":
+		return "bing"
+	}
+	return ""
+}
+
+// ResolveAll 并发解析结果中的跳转链接，成功时 URL 替换为真实地址，原链接保存在 OriginalURL
+func (r *RedirectResolver) ResolveAll(ctx context.Context, results []SearchResult) {
+	var wg sync.WaitGroup
+
+	for i := range results {
+		if !isTrackingLink(results[i].URL) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(res *SearchResult) {
+			defer wg.Done()
+
+			target, err := r.Resolve(ctx, res.URL)
+			if err != nil {
+				log.Printf("⚠️ Resolve redirect failed for %s: %v", res.URL, err)
+				return
+			}
+
+			res.OriginalURL = res.URL
+			res.URL = target
+			if res.Source == "" {
+				res.Source = hostOf(target)
+			}
+		}(&results[i])
+	}
+
+	wg.Wait()
+}
+
+// Resolve 解析单个跳转链接
+func (r *RedirectResolver) Resolve(ctx context.Context, link string) (string, error) {
+	if target, ok := r.cached(link); ok {
+		return target, nil
+	}
+
+	// Bing 的 ck/a 链接把目标地址编码在 u 参数中，无需请求
+	target, ok := decodeBingClickURL(link)
+	if !ok {
+		target, ok = decodeSogouLinkURL(link)
+	}
+	if !ok {
+		select {
+		case r.sem <- struct{}{}:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		var err error
+		target, err = r.fetchTarget(ctx, link)
+		<-r.sem
+		if err != nil {
+			return "", err
+		}
+	}
+
+	r.store(link, target)
+	return target, nil
+}
+
+// fetchTarget 先用 HEAD 读取 Location，没有时再 GET 跳转页解析脚本或 meta refresh
+func (r *RedirectResolver) fetchTarget(ctx context.Context, link string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, redirectTimeout)
+	defer cancel()
+
+	for _, method := range []string{"HEAD", "GET"} {
+		req, err := http.NewRequestWithContext(ctx, method, link, nil)
+		if err != nil {
+			return "", fmt.Errorf("create request failed: %w", err)
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+		resp, err := r.client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("request failed: %w", err)
+		}
+
+		if location := resp.Header.Get("Location"); location != "" {
+			resp.Body.Close()
+			return absoluteTarget(link, location)
+		}
+
+		if method == "GET" {
+			// 跳转页很小，只读取开头部分
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+			for _, pattern := range redirectTargetPatterns {
+				if m := pattern.FindSubmatch(body); m != nil {
+					return absoluteTarget(link, string(m[1]))
+				}
+			}
+		} else {
+			resp.Body.Close()
+		}
+	}
+
+	return "", fmt.Errorf("no redirect target found")
+}
+
+// absoluteTarget 将跳转目标转换为绝对地址，并拒绝仍指向跳转服务的结果
+func absoluteTarget(link, location string) (string, error) {
+	base, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+	target, err := base.Parse(strings.TrimSpace(location))
+	if err != nil {
+		return "", fmt.Errorf("invalid redirect target: %s", location)
+	}
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return "", fmt.Errorf("invalid redirect target: %s", location)
+	}
+	if trackingLinkEngine(target) != "" {
+		return "", fmt.Errorf("redirect target is still a tracking link: %s", target)
+	}
+	return target.String(), nil
+}
+
+// decodeBingClickURL 从 Bing ck/a 链接的 u 参数（a1 + Base64）中还原目标地址
+func decodeBingClickURL(link string) (string, bool) {
+	if !strings.Contains(link, "bing.com/ck/a") {
+		return "", false
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", false
+	}
+
+	u := strings.TrimPrefix(parsed.Query().Get("u"), "a1")
+	if u == "" {
+		return "", false
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(u, "="))
+	if err != nil || !strings.HasPrefix(string(decoded), "http") {
+		return "", false
+	}
+	return string(decoded), true
+}
+
+// decodeSogouLinkURL 部分搜狗跳转链接直接在 url 参数中携带明文地址
+func decodeSogouLinkURL(link string) (string, bool) {
+	if !strings.Contains(link, "sogou.com/link?") {
+		return "", false
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", false
+	}
+
+	target := parsed.Query().Get("url")
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		return target, true
+	}
+	return "", false
+}
+
+// cached 读取缓存
+func (r *RedirectResolver) cached(link string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	target, ok := r.cache[link]
+	return target, ok
+}
+
+// store 写入缓存，超出容量时淘汰最早的条目
+func (r *RedirectResolver) store(link, target string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.cache[link]; ok {
+		return
+	}
+	if len(r.order) >= redirectCacheSize {
+		delete(r.cache, r.order[0])
+		r.order = r.order[1:]
+	}
+	r.cache[link] = target
+	r.order = append(r.order, link)
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/config"
+)
+
+func TestTrackingLinkEngine(t *testing.T) {
+	tests := []struct {
+		link   string
+		engine string
+	}{
+		{"https://www.baidu.com/link?url=abc", "baidu"},
+		{"https://m.baidu.com/link?url=abc", "baidu"},
+		{"https://www.sogou.com/link?url=abc", "sogou"},
+		{"https://www.bing.com/ck/a?u=a1aHR0cHM6Ly9nby5kZXY", "bing"},
+		{"https://www.baidu.com/s?wd=go", ""},
+		{"https://go.dev/doc/", ""},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.link)
+		if got := trackingLinkEngine(u); got != tt.engine {
+			t.Errorf("trackingLinkEngine(%s) = %q, want %q", tt.link, got, tt.engine)
+		}
+	}
+}
+
+func TestAbsoluteTarget(t *testing.T) {
+	tests := []struct {
+		location string
+		want     string
+		wantErr  bool
+	}{
+		{"https://go.dev/doc/", "https://go.dev/doc/", false},
+		{"/s?wd=go", "https://www.baidu.com/s?wd=go", false},
+		{"javascript:void(0)", "", true},
+		// 仍指向跳转服务的目标没有解析出真实地址
+		{"https://www.baidu.com/link?url=other", "", true},
+	}
+	for _, tt := range tests {
+		got, err := absoluteTarget("https://www.baidu.com/link?url=abc", tt.location)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("absoluteTarget(%q) = %q, %v; want %q, error=%v", tt.location, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDecodeBingClickURL(t *testing.T) {
+	got, ok := decodeBingClickURL("https://www.bing.com/ck/a?!&&p=x&u=a1aHR0cHM6Ly9nby5kZXYvZG9jLw&ntb=1")
+	if !ok || got != "https://go.dev/doc/" {
+		t.Fatalf("decodeBingClickURL = %q, %v", got, ok)
+	}
+}
+
+// blockingTransport 直到请求的上下文结束才返回
+type blockingTransport struct{}
+
+func (blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestSearchResolvesRedirectsWithinEngineDeadline(t *testing.T) {
+	baidu := newStubEngine("baidu", 2)
+	baidu.results[0].URL = "https://www.baidu.com/link?url=abc"
+	m := newTestManagerWith(t, func(cfg *config.Config) {
+		cfg.Search.EngineTimeoutsMS = map[string]int{"baidu": 50}
+	}, baidu)
+	m.resolver = &RedirectResolver{
+		client: &http.Client{Transport: blockingTransport{}},
+		sem:    make(chan struct{}, redirectConcurrency),
+		cache:  make(map[string]string),
+	}
+
+	start := time.Now()
+	resp, err := m.Search(context.Background(), SearchRequest{Query: "golang", Limit: 5, Engines: []string{"baidu"}})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Search() took %s, want redirect resolution stopped at the 50ms engine deadline", elapsed)
+	}
+	// 未解析的链接保持原样，搜索本身已成功
+	if len(resp.Results) != 2 || resp.Outcomes[0].Status != OutcomeOK {
+		t.Fatalf("Results = %+v, Outcomes = %+v", resp.Results, resp.Outcomes)
+	}
+	if resp.Results[0].URL != "https://www.baidu.com/link?url=abc" || resp.Results[0].OriginalURL != "" {
+		t.Errorf("results[0] = %+v, want the unresolved tracking link", resp.Results[0])
+	}
+}
+
+func TestRedirectResolverHasOwnRateLimiters(t *testing.T) {
+	m := newTestManagerWith(t, func(cfg *config.Config) {
+		cfg.Search.ResolveRedirects = true
+	})
+	transport, ok := m.resolver.client.Transport.(*rateLimitedTransport)
+	if !ok {
+		t.Fatalf("resolver transport = %T", m.resolver.client.Transport)
+	}
+	if transport.limiters["baidu"] == nil || transport.limiters["baidu"] == m.limiters["baidu"] {
+		t.Error("resolver should rate-limit baidu with its own token bucket")
+	}
+}
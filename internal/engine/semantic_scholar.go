@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SemanticScholarEngine Semantic Scholar 论文搜索引擎实现
+type SemanticScholarEngine struct {
+	client   *http.Client
+	proxyURL string
+}
+
+// NewSemanticScholarEngine 创建 Semantic Scholar 搜索引擎实例
+func NewSemanticScholarEngine(proxyURL string, limiter *RateLimiter) *SemanticScholarEngine {
+	transport := &http.Transport{}
+	if proxyURL != "" {
+		if proxy, err := url.Parse(proxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+
+	client := &http.Client{
+		Transport: newRateLimitedTransport(limiter, transport),
+	}
+
+	return &SemanticScholarEngine{
+		client:   client,
+		proxyURL: proxyURL,
+	}
+}
+
+// Name 返回引擎名称
+func (e *SemanticScholarEngine) Name() string {
+	return "semantic_scholar"
+}
+
+// Capabilities 返回引擎能力描述
+func (e *SemanticScholarEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryAcademic,
+		Description: "Semantic Scholar papers with citation counts",
+		Verticals:   webOnly,
+		Filters:     []string{FilterFreshness, FilterDateRange},
+		MaxResults:  100,
+		Pagination:  true,
+		RegionFocus: RegionGlobal,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符（短语、- 排除和 | 或）
+func (e *SemanticScholarEngine) QueryDialect() QueryDialect {
+	return QueryDialect{Phrase: true, Exclude: true, OR: "|"}
+}
+
+// semanticScholarResponse 论文搜索接口响应
+type semanticScholarResponse struct {
+	Total int                    `json:"total"`
+	Data  []semanticScholarPaper `json:"data"`
+}
+
+// semanticScholarPaper 单篇论文
+type semanticScholarPaper struct {
+	PaperID  string `json:"paperId"`
+	URL      string `json:"url"`
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+	Venue    string `json:"venue"`
+	Year     int    `json:"year"`
+	Authors  []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	ExternalIDs   map[string]interface{} `json:"externalIds"`
+	OpenAccessPDF *struct {
+		URL string `json:"url"`
+	} `json:"openAccessPdf"`
+}
+
+// Search 执行 Semantic Scholar 搜索
+func (e *SemanticScholarEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	// 接口单次最多返回 100 条
+	size := limit
+	if size > 100 {
+		size = 100
+	}
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("limit", fmt.Sprintf("%d", size))
+	if opts.Offset > 0 {
+		params.Set("offset", fmt.Sprintf("%d", opts.Offset))
+	}
+	params.Set("fields", "title,url,abstract,venue,year,authors,externalIds,openAccessPdf")
+	if from, to, ok := opts.timeRange(time.Now()); ok {
+		params.Set("publicationDateOrYear", from.Format(dateLayout)+":"+to.Format(dateLayout))
+	}
+
+	searchURL := fmt.Sprintf("https://api.semanticscholar.org/graph/v1/paper/search?%s", params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	req.Header.Set("User-Agent", "go-web-search-mcp/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("semantic scholar rate limited: too many requests")
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	var data semanticScholarResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("parse JSON failed: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(data.Data))
+	for _, paper := range data.Data {
+		if result := e.parsePaper(paper); result != nil {
+			results = append(results, *result)
+		}
+	}
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	log.Printf("🔍 Semantic Scholar: found %d results for query '%s'", len(results), query)
+	return results, nil
+}
+
+// parsePaper 解析单篇论文
+func (e *SemanticScholarEngine) parsePaper(paper semanticScholarPaper) *SearchResult {
+	title := cleanText(paper.Title)
+	if title == "" {
+		return nil
+	}
+
+	href := paper.URL
+	if href == "" && paper.PaperID != "" {
+		href = "https://www.semanticscholar.org/paper/" + paper.PaperID
+	}
+	if href == "" {
+		return nil
+	}
+
+	authors := make([]string, 0, len(paper.Authors))
+	for _, a := range paper.Authors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			authors = append(authors, name)
+		}
+	}
+
+	doi := ""
+	if v, ok := paper.ExternalIDs["DOI"].(string); ok {
+		doi = v
+	}
+
+	pdfURL := ""
+	if paper.OpenAccessPDF != nil {
+		pdfURL = paper.OpenAccessPDF.URL
+	}
+
+	return &SearchResult{
+		Title:       title,
+		URL:         href,
+		Description: truncateText(cleanText(paper.Abstract), 500),
+		Source:      "semanticscholar.org",
+		Engine:      "semantic_scholar",
+		Authors:     authors,
+		Venue:       paper.Venue,
+		Year:        paper.Year,
+		DOI:         doi,
+		PDFURL:      pdfURL,
+	}
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSemanticScholarSearch(t *testing.T) {
+	var query string
+	e := &SemanticScholarEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		query = req.URL.RawQuery
+		return http.StatusOK, readFixture(t, "semantic_scholar.json")
+	})}
+
+	results, err := e.Search(context.Background(), "attention", 10, SearchOptions{Offset: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := queryParams(t, query)
+	if params.Get("query") != "attention" || params.Get("limit") != "10" || params.Get("offset") != "30" {
+		t.Errorf("request params = %v", params)
+	}
+
+	// 既没有 URL 也没有 paperId 的论文被跳过
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	want := SearchResult{
+		Title:       "Attention is All you Need",
+		URL:         "https://www.semanticscholar.org/paper/204e3073870fae3d05bcbc2f6a8e263d9b72e776",
+		Description: "The dominant sequence transduction models.",
+		Source:      "semanticscholar.org",
+		Engine:      "semantic_scholar",
+		Authors:     []string{"Ashish Vaswani"},
+		Venue:       "Neural Information Processing Systems",
+		Year:        2017,
+		DOI:         "10.48550/arXiv.1706.03762",
+		PDFURL:      "https://arxiv.org/pdf/1706.03762.pdf",
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("results[0] = %+v\nwant %+v", results[0], want)
+	}
+
+	// 没有 URL 时用 paperId 拼接论文页，externalIds 和 openAccessPdf 为 null 时不出错
+	if r := results[1]; r.URL != "https://www.semanticscholar.org/paper/abc123" || r.DOI != "" || r.PDFURL != "" {
+		t.Errorf("results[1] = %+v", r)
+	}
+}
+
+func TestSemanticScholarRateLimited(t *testing.T) {
+	e := &SemanticScholarEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		return http.StatusTooManyRequests, `{"message": "Too Many Requests"}`
+	})}
+	_, err := e.Search(context.Background(), "attention", 10, SearchOptions{})
+	if err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Errorf("Search() error = %v, want a rate-limit error", err)
+	}
+}
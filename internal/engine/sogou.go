@@ -9,7 +9,6 @@ import (
 	"net/http/cookiejar"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -21,7 +20,7 @@ type SogouEngine struct {
 }
 
 // NewSogouEngine 创建搜狗搜索引擎实例
-func NewSogouEngine(proxyURL string) *SogouEngine {
+func NewSogouEngine(proxyURL string, limiter *RateLimiter) *SogouEngine {
 	jar, _ := cookiejar.New(nil)
 
 	transport := &http.Transport{}
@@ -32,9 +31,8 @@ func NewSogouEngine(proxyURL string) *SogouEngine {
 	}
 
 	client := &http.Client{
-		Timeout:   30 * time.Second,
 		Jar:       jar,
-		Transport: transport,
+		Transport: newRateLimitedTransport(limiter, transport),
 	}
 
 	return &SogouEngine{
@@ -48,12 +46,34 @@ func (e *SogouEngine) Name() string {
 	return "sogou"
 }
 
+// Capabilities 返回引擎能力描述
+func (e *SogouEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryWeb,
+		Description: "Sogou mobile web search",
+		Verticals:   webOnly,
+		Filters:     []string{},
+		MaxResults:  50,
+		Pagination:  true,
+		RegionFocus: RegionCN,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符（搜狗不支持 -site: 和 OR）
+func (e *SogouEngine) QueryDialect() QueryDialect {
+	return QueryDialect{Site: true, FileType: true, InTitle: true, Phrase: true, Exclude: true}
+}
+
 // Search 执行搜狗搜索
-func (e *SogouEngine) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+func (e *SogouEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	// 页码从 1 开始，每页约 10 条
+	page, skip := pageWindow(opts.Offset, 10)
+	page++
+	startPage := page
+
 	var allResults []SearchResult
-	page := 1
 
-	for len(allResults) < limit {
+	for len(allResults) < skip+limit {
 		results, err := e.searchPage(ctx, query, page)
 		if err != nil {
 			if len(allResults) > 0 {
@@ -72,21 +92,12 @@ func (e *SogouEngine) Search(ctx context.Context, query string, limit int) ([]Se
 		page++
 
 		// 限制最多搜索5页
-		if page > 5 {
+		if page >= startPage+5 {
 			break
 		}
-
-		// 添加延迟避免触发限制
-		if page <= 5 && len(allResults) < limit {
-			time.Sleep(300 * time.Millisecond)
-		}
-	}
-
-	if len(allResults) > limit {
-		allResults = allResults[:limit]
 	}
 
-	return allResults, nil
+	return window(allResults, skip, limit), nil
 }
 
 // searchPage 搜索单页结果（使用移动端页面，更稳定）
@@ -127,7 +138,7 @@ func (e *SogouEngine) searchPage(ctx context.Context, query string, page int) ([
 	log.Printf("🔍 Sogou response size: %d bytes", len(body))
 
 	// 检查是否被重定向到反爬页面
-	if strings.Contains(bodyStr, "antispider") || strings.Contains(bodyStr, "验证码") {
+	if isSogouAntiSpider(resp.Request.URL.String(), bodyStr) {
 		return nil, fmt.Errorf("sogou rate limited: anti-spider triggered")
 	}
 
@@ -151,6 +162,13 @@ func (e *SogouEngine) setHeaders(req *http.Request) {
 	req.Header.Set("Referer", "https://wap.sogou.com/")
 }
 
+// isSogouAntiSpider 判断是否触发了搜狗反爬（跳转到 antispider 页面或出现验证码）
+func isSogouAntiSpider(finalURL, body string) bool {
+	return strings.Contains(finalURL, "antispider") ||
+		strings.Contains(body, "antispider") ||
+		strings.Contains(body, "验证码")
+}
+
 // parseResults 解析搜索结果
 func (e *SogouEngine) parseResults(doc *goquery.Document) []SearchResult {
 	var results []SearchResult
@@ -297,7 +315,6 @@ func (e *SogouEngine) isInternalLink(href, title string) bool {
 	// 过滤搜狗内部链接
 	internalPatterns := []string{
 		"sogou.com/web/searchList",
-		"sogou.com/link?",
 		"sogou.com/tx?",
 		"sogou.com/v?",
 		"antispider",
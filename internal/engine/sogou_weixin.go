@@ -0,0 +1,363 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SogouWeixinEngine 搜狗微信公众号文章搜索引擎实现
+type SogouWeixinEngine struct {
+	client   *http.Client
+	proxyURL string
+}
+
+// NewSogouWeixinEngine 创建搜狗微信搜索引擎实例
+func NewSogouWeixinEngine(proxyURL string, limiter *RateLimiter) *SogouWeixinEngine {
+	jar, _ := cookiejar.New(nil)
+
+	transport := &http.Transport{}
+	if proxyURL != "" {
+		if proxy, err := url.Parse(proxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+
+	client := &http.Client{
+		Jar:       jar,
+		Transport: newRateLimitedTransport(limiter, transport),
+	}
+
+	return &SogouWeixinEngine{
+		client:   client,
+		proxyURL: proxyURL,
+	}
+}
+
+// Name 返回引擎名称
+func (e *SogouWeixinEngine) Name() string {
+	return "sogou_weixin"
+}
+
+// Capabilities 返回引擎能力描述
+func (e *SogouWeixinEngine) Capabilities() Capabilities {
+	return Capabilities{
+		Category:    CategoryWeb,
+		Description: "WeChat official account articles via Sogou",
+		Verticals:   webOnly,
+		Filters:     []string{FilterFreshness, FilterDateRange},
+		MaxResults:  50,
+		Pagination:  true,
+		RegionFocus: RegionCN,
+	}
+}
+
+// QueryDialect 返回支持的查询操作符（微信搜索不支持任何操作符）
+func (e *SogouWeixinEngine) QueryDialect() QueryDialect {
+	return QueryDialect{}
+}
+
+// weixinURLFragmentPattern 跳转页中拼接真实地址的 JS 片段: url += '...';
+var weixinURLFragmentPattern = regexp.MustCompile(`url \+= '([^']*)'`)
+
+// Search 执行搜狗微信文章搜索
+func (e *SogouWeixinEngine) Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error) {
+	// 先访问首页获取 SNUID 等 cookie，跳转链接解析依赖这些 cookie
+	if err := e.warmup(ctx); err != nil {
+		log.Printf("⚠️ Sogou Weixin warmup failed: %v", err)
+	}
+
+	// 页码从 1 开始，每页 10 条
+	page, skip := pageWindow(opts.Offset, 10)
+	page++
+	startPage := page
+
+	var allResults []SearchResult
+
+	for len(allResults) < skip+limit {
+		results, err := e.searchPage(ctx, query, page, opts)
+		if err != nil {
+			if len(allResults) > 0 {
+				log.Printf("⚠️ Sogou Weixin: Error on page %d, returning %d results collected so far: %v", page, len(allResults), err)
+				break
+			}
+			return nil, err
+		}
+
+		if len(results) == 0 {
+			log.Printf("⚠️ Sogou Weixin: No more results at page %d, ending early", page)
+			break
+		}
+
+		allResults = append(allResults, results...)
+		page++
+
+		// 限制最多搜索5页
+		if page >= startPage+5 {
+			break
+		}
+	}
+
+	allResults = window(allResults, skip, limit)
+
+	e.resolveLinks(ctx, allResults, query)
+
+	return allResults, nil
+}
+
+// warmup 访问搜狗微信首页获取初始 cookie
+func (e *SogouWeixinEngine) warmup(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://weixin.sogou.com/", nil)
+	if err != nil {
+		return err
+	}
+
+	e.setHeaders(req)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// searchPage 搜索单页结果
+func (e *SogouWeixinEngine) searchPage(ctx context.Context, query string, page int, opts SearchOptions) ([]SearchResult, error) {
+	params := url.Values{}
+	params.Set("type", "2") // 2 表示搜文章，1 表示搜公众号
+	params.Set("query", query)
+	params.Set("ie", "utf8")
+	if page > 1 {
+		params.Set("page", fmt.Sprintf("%d", page))
+	}
+
+	// 时间范围：tsn=1 一天内，2 一周内，3 一月内，4 一年内，5 自定义（ft/et）
+	switch opts.Freshness {
+	case FreshnessDay:
+		params.Set("tsn", "1")
+	case FreshnessWeek:
+		params.Set("tsn", "2")
+	case FreshnessMonth:
+		params.Set("tsn", "3")
+	case FreshnessYear:
+		params.Set("tsn", "4")
+	case FreshnessCustom:
+		from, to, _ := opts.timeRange(time.Now())
+		params.Set("tsn", "5")
+		params.Set("ft", from.Format(dateLayout))
+		params.Set("et", to.Format(dateLayout))
+	}
+
+	searchURL := fmt.Sprintf("https://weixin.sogou.com/weixin?%s", params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+
+	e.setHeaders(req)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read body failed: %w", err)
+	}
+
+	bodyStr := string(body)
+	log.Printf("🔍 Sogou Weixin response size: %d bytes", len(body))
+
+	// 与网页搜索共用反爬检测
+	if isSogouAntiSpider(resp.Request.URL.String(), bodyStr) {
+		return nil, fmt.Errorf("sogou weixin rate limited: anti-spider triggered")
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(bodyStr))
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML failed: %w", err)
+	}
+
+	results := e.parseResults(doc)
+	log.Printf("🔍 Sogou Weixin page %d: found %d results", page, len(results))
+
+	return results, nil
+}
+
+// setHeaders 设置请求头
+func (e *SogouWeixinEngine) setHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
+	req.Header.Set("Referer", "https://weixin.sogou.com/")
+}
+
+// parseResults 解析搜索结果
+func (e *SogouWeixinEngine) parseResults(doc *goquery.Document) []SearchResult {
+	var results []SearchResult
+
+	// 文章结果在 ul.news-list 的 li 中
+	doc.Find("ul.news-list > li").Each(func(i int, s *goquery.Selection) {
+		result := e.parseResultItem(s)
+		if result != nil {
+			results = append(results, *result)
+		}
+	})
+
+	return results
+}
+
+// parseResultItem 解析单个搜索结果项
+func (e *SogouWeixinEngine) parseResultItem(s *goquery.Selection) *SearchResult {
+	linkEl := s.Find(".txt-box h3 a").First()
+	if linkEl.Length() == 0 {
+		return nil
+	}
+
+	title := strings.TrimSpace(linkEl.Text())
+	href, _ := linkEl.Attr("href")
+	if title == "" || href == "" {
+		return nil
+	}
+
+	// 搜狗返回的是 /link?url=... 跳转链接，补全为绝对地址
+	if strings.HasPrefix(href, "/") {
+		href = "https://weixin.sogou.com" + href
+	}
+
+	description := strings.TrimSpace(s.Find("p.txt-info").First().Text())
+
+	// 公众号名称（新旧版页面结构不同）
+	account := strings.TrimSpace(s.Find(".s-p .all-time-y2").First().Text())
+	if account == "" {
+		account = strings.TrimSpace(s.Find(".s-p a.account").First().Text())
+	}
+
+	// 发布时间以 Unix 时间戳存放在 .s-p 的 t 属性中
+	publishedAt := ""
+	if ts, exists := s.Find(".s-p").First().Attr("t"); exists {
+		if sec, err := strconv.ParseInt(ts, 10, 64); err == nil && sec > 0 {
+			publishedAt = time.Unix(sec, 0).UTC().Format(time.RFC3339)
+		}
+	}
+
+	return &SearchResult{
+		Title:       title,
+		URL:         href,
+		Description: truncateText(description, 500),
+		Source:      "mp.weixin.qq.com",
+		Engine:      "sogou_weixin",
+		Publisher:   account,
+		PublishedAt: publishedAt,
+	}
+}
+
+// resolveLinks 并发将搜狗跳转链接解析为 mp.weixin.qq.com 真实地址，失败时保留原链接
+func (e *SogouWeixinEngine) resolveLinks(ctx context.Context, results []SearchResult, query string) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 4)
+
+	for i := range results {
+		if !strings.Contains(results[i].URL, "weixin.sogou.com/link?") {
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *SearchResult) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			realURL, err := e.resolveLink(ctx, r.URL, query)
+			if err != nil {
+				log.Printf("⚠️ Sogou Weixin: resolve link failed: %v", err)
+				return
+			}
+			// 与通用跳转解析一致，原链接保存在 OriginalURL
+			r.OriginalURL = r.URL
+			r.URL = realURL
+		}(&results[i])
+	}
+
+	wg.Wait()
+}
+
+// resolveLink 解析单个跳转链接
+func (e *SogouWeixinEngine) resolveLink(ctx context.Context, href, query string) (string, error) {
+	// 模拟页面点击时追加的 k/h 参数，缺少时跳转页会返回反爬
+	k := rand.Intn(100) + 1
+	if idx := strings.Index(href, "url="); idx >= 0 && idx+4+21+k < len(href) {
+		pos := idx + 4 + 21 + k
+		href += fmt.Sprintf("&k=%d&h=%s", k, href[pos:pos+1])
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", href, nil)
+	if err != nil {
+		return "", fmt.Errorf("create request failed: %w", err)
+	}
+
+	e.setHeaders(req)
+	req.Header.Set("Referer", "https://weixin.sogou.com/weixin?type=2&query="+url.QueryEscape(query))
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 部分情况下会直接 302 到文章页
+	if strings.Contains(resp.Request.URL.Host, "mp.weixin.qq.com") {
+		return resp.Request.URL.String(), nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read body failed: %w", err)
+	}
+
+	bodyStr := string(body)
+	if isSogouAntiSpider(resp.Request.URL.String(), bodyStr) {
+		return "", fmt.Errorf("sogou weixin rate limited: anti-spider triggered")
+	}
+
+	// 跳转页通过多段 url += '...' 拼接真实地址，并插入 @ 干扰
+	matches := weixinURLFragmentPattern.FindAllStringSubmatch(bodyStr, -1)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no redirect target found")
+	}
+
+	var sb strings.Builder
+	for _, m := range matches {
+		sb.WriteString(m[1])
+	}
+	realURL := strings.ReplaceAll(sb.String(), "@", "")
+
+	if !strings.HasPrefix(realURL, "http") {
+		return "", fmt.Errorf("invalid redirect target: %s", realURL)
+	}
+
+	return realURL, nil
+}
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// weixinRedirectPage 跳转页通过多段 url += '...' 拼接文章地址，并插入 @ 干扰
+const weixinRedirectPage = `<script>
+var url = '';
+url += 'https://mp.w';
+url += 'eixin.qq.com/s?src=11&timestamp=1706745600&ver=5056&signature=abc@';
+url += 'def';
+window.location.replace(url);
+</script>`
+
+func TestSogouWeixinSearch(t *testing.T) {
+	var pages []string
+	e := &SogouWeixinEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		switch req.URL.Path {
+		case "/weixin":
+			page := req.URL.Query().Get("page")
+			pages = append(pages, page)
+			if page == "" {
+				return http.StatusOK, readFixture(t, "sogou_weixin.html")
+			}
+			return http.StatusOK, "<html><ul class=\"news-list\"></ul></html>"
+		case "/link":
+			return http.StatusOK, weixinRedirectPage
+		}
+		return http.StatusOK, ""
+	})}
+
+	results, err := e.Search(context.Background(), "go 并发", 10, SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 第二页没有结果时停止翻页
+	if len(pages) != 2 || pages[1] != "2" {
+		t.Errorf("requested pages = %q, want first page and page 2", pages)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	first := results[0]
+	if first.Title != "Go 语言并发编程实践" || first.Description != "goroutine 与 channel 的常见用法。" {
+		t.Errorf("results[0] text = %+v", first)
+	}
+	if first.Publisher != "Gopher 学院" || first.PublishedAt != "2024-02-01T00:00:00Z" || first.Source != "mp.weixin.qq.com" {
+		t.Errorf("results[0] metadata = %+v", first)
+	}
+	// 跳转链接解析为真实文章地址，原链接保存在 OriginalURL
+	if first.URL != "https://mp.weixin.qq.com/s?src=11&timestamp=1706745600&ver=5056&signature=abcdef" {
+		t.Errorf("results[0].URL = %s", first.URL)
+	}
+	if !strings.HasPrefix(first.OriginalURL, "https://weixin.sogou.com/link?url=") {
+		t.Errorf("results[0].OriginalURL = %s", first.OriginalURL)
+	}
+
+	// 已经是文章地址的链接不再解析；旧版页面的公众号名称在 a.account 中
+	second := results[1]
+	if second.URL != "https://mp.weixin.qq.com/s/direct-article" || second.OriginalURL != "" {
+		t.Errorf("results[1] URL = %s, original = %s", second.URL, second.OriginalURL)
+	}
+	if second.Publisher != "云原生周刊" || second.PublishedAt != "" {
+		t.Errorf("results[1] metadata = %+v", second)
+	}
+}
+
+func TestSogouWeixinAntiSpider(t *testing.T) {
+	e := &SogouWeixinEngine{client: fixtureClient(func(req *http.Request) (int, string) {
+		if req.URL.Path == "/weixin" {
+			return http.StatusOK, "<html>请输入验证码</html>"
+		}
+		return http.StatusOK, ""
+	})}
+
+	_, err := e.Search(context.Background(), "golang", 10, SearchOptions{})
+	if err == nil || !strings.Contains(err.Error(), "anti-spider") {
+		t.Errorf("Search() error = %v, want anti-spider error", err)
+	}
+}
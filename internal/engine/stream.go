@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"context"
+	"sync"
+)
+
+// 流式搜索事件类型
+const (
+	// EventStart 确定了本次使用的引擎
+	EventStart = "start"
+	// EventEngine 某个引擎（包括备用引擎）完成，附带其结果
+	EventEngine = "engine"
+	// EventDone 所有引擎完成，附带合并后的完整响应
+	EventDone = "done"
+	// EventError 搜索失败
+	EventError = "error"
+)
+
+// SearchEvent 流式搜索事件
+type SearchEvent struct {
+	Type string `json:"type"`
+	// Engines 本次使用的引擎（start）
+	Engines []string      `json:"engines,omitempty"`
+	Auto    *AutoDecision `json:"auto,omitempty"`
+	// Engine/Outcome/Results 完成的引擎、执行情况和经过处理流水线的结果（engine）
+	Engine  string         `json:"engine,omitempty"`
+	Outcome *EngineOutcome `json:"outcome,omitempty"`
+	Results []SearchResult `json:"results,omitempty"`
+	// Response 合并后的完整响应，与 Search 的返回值相同（done）
+	Response *SearchResponse `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// SearchStream 流式搜索：先发送 start 事件，每个引擎完成时立即发送 engine 事件，最后发送 done 或 error 事件
+// engine 事件中的结果只来自该引擎，跨引擎合并、去重和数量限制以 done 事件中的响应为准
+// 命中缓存时按缓存中记录的各引擎执行情况补发 engine 事件，结果取自缓存的合并结果；emit 不会被并发调用
+func (m *Manager) SearchStream(ctx context.Context, req SearchRequest, emit func(SearchEvent)) (*SearchResponse, error) {
+	var mu sync.Mutex
+	send := func(event SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		emit(event)
+	}
+
+	resp, err := m.searchWithEvents(ctx, req, send)
+	if err != nil {
+		send(SearchEvent{Type: EventError, Error: err.Error()})
+		return nil, err
+	}
+	send(SearchEvent{Type: EventDone, Response: resp})
+	return resp, nil
+}
+
+// engineEvent 生成引擎完成事件，结果单独经过处理流水线（过滤广告、域名规则等）后按数量截断，便于调用方直接展示
+func (m *Manager) engineEvent(ctx context.Context, req SearchRequest, parsed *ParsedQuery, outcome EngineOutcome, results []SearchResult, limit int) SearchEvent {
+	event := SearchEvent{Type: EventEngine, Engine: outcome.Engine, Outcome: &outcome}
+	if len(results) == 0 {
+		return event
+	}
+
+	pc := &ProcessContext{
+		Request: req,
+		Query:   parsed,
+		Rules:   m.domainRules().Merge(req.DomainRules),
+	}
+	processed := m.pipeline.Run(ctx, pc, mergeResults([]string{outcome.Engine}, map[string][]SearchResult{outcome.Engine: results}))
+	event.Outcome.Filtered = pc.FilteredBy[outcome.Engine]
+	event.Outcome.Dropped = pc.DroppedBy[outcome.Engine]
+	processed, _ = capPerDomain(processed, req.MaxPerDomain)
+	if len(processed) > limit {
+		processed = processed[:limit]
+	}
+	event.Results = processed
+	return event
+}
+
+// cachedEngineEvents 根据缓存响应的 outcomes 生成各引擎的 engine 事件，结果为合并结果中来自该引擎的部分
+func cachedEngineEvents(resp *SearchResponse) []SearchEvent {
+	events := make([]SearchEvent, 0, len(resp.Outcomes))
+	for _, outcome := range resp.Outcomes {
+		outcome := outcome
+		event := SearchEvent{Type: EventEngine, Engine: outcome.Engine, Outcome: &outcome}
+		for _, r := range resp.Results {
+			if r.Engine == outcome.Engine || hasEngine(r.Engines, outcome.Engine) {
+				event.Results = append(event.Results, r)
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// 命中缓存时也按各引擎发送 engine 事件
+func TestSearchStreamCachedEmitsEngineEvents(t *testing.T) {
+	m := newTestManager(t, newStubEngine("bing", 5), newStubEngine("duckduckgo", 5))
+	m.cache = NewMemoryCache(time.Minute, 10)
+	req := SearchRequest{Query: "stream", Limit: 6, Engines: []string{"bing", "duckduckgo"}}
+
+	run := func() (*SearchResponse, map[string]int) {
+		counts := make(map[string]int)
+		resp, err := m.SearchStream(context.Background(), req, func(event SearchEvent) {
+			if event.Type == EventEngine {
+				counts[event.Engine] += len(event.Results)
+			}
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp, counts
+	}
+
+	if resp, counts := run(); resp.Cached || counts["bing"] == 0 || counts["duckduckgo"] == 0 {
+		t.Fatalf("first search: cached=%v engine results=%v", resp.Cached, counts)
+	}
+	resp, counts := run()
+	if !resp.Cached {
+		t.Fatal("second search was not served from cache")
+	}
+	if len(counts) != 2 || counts["bing"] == 0 || counts["duckduckgo"] == 0 {
+		t.Errorf("cached search engine results = %v, want events for both engines", counts)
+	}
+	if counts["bing"]+counts["duckduckgo"] != len(resp.Results) {
+		t.Errorf("cached events carry %v results, response has %d", counts, len(resp.Results))
+	}
+}
@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	tagPattern        = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// cleanText 去除 HTML/XML 标签并合并多余空白
+func cleanText(s string) string {
+	s = tagPattern.ReplaceAllString(s, " ")
+	s = whitespacePattern.ReplaceAllString(s, " ")
+	return strings.TrimSpace(s)
+}
+
+// truncateText 按字符截断文本，超出部分以 "..." 结尾
+func truncateText(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes]) + "..."
+}
+
+var relativeTimePattern = regexp.MustCompile(`^(\d+)\s*(秒|分钟|小时|天|周|个月|年|s|sec|secs|m|min|mins|minute|minutes|h|hr|hrs|hour|hours|d|day|days|w|week|weeks|mo|month|months|y|year|years)\s*(前|ago)?$`)
+
+// parseDisplayTime 将搜索结果页上的时间文本（"3小时前"、"2h"、"2024-01-02" 等）转换为 RFC3339，无法识别时返回空字符串
+func parseDisplayTime(s string, now time.Time) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+
+	if m := relativeTimePattern.FindStringSubmatch(strings.ToLower(s)); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		var t time.Time
+		switch m[2] {
+		case "秒", "s", "sec", "secs":
+			t = now.Add(-time.Duration(n) * time.Second)
+		case "分钟", "m", "min", "mins", "minute", "minutes":
+			t = now.Add(-time.Duration(n) * time.Minute)
+		case "小时", "h", "hr", "hrs", "hour", "hours":
+			t = now.Add(-time.Duration(n) * time.Hour)
+		case "天", "d", "day", "days":
+			t = now.AddDate(0, 0, -n)
+		case "周", "w", "week", "weeks":
+			t = now.AddDate(0, 0, -7*n)
+		case "个月", "mo", "month", "months":
+			t = now.AddDate(0, -n, 0)
+		default:
+			t = now.AddDate(-n, 0, 0)
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	switch s {
+	case "昨天":
+		return now.AddDate(0, 0, -1).UTC().Format(time.RFC3339)
+	case "前天":
+		return now.AddDate(0, 0, -2).UTC().Format(time.RFC3339)
+	}
+
+	layouts := []string{
+		time.RFC3339,
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+		"2006年01月02日 15:04",
+		"2006年01月02日",
+		"2006年1月2日",
+		"Jan 2, 2006",
+		"01-02 15:04",
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			// 省略年份的格式默认为今年
+			if t.Year() == 0 {
+				t = t.AddDate(now.Year(), 0, 0)
+			}
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return ""
+}
@@ -9,6 +9,35 @@ type SearchResult struct {
 	Description string `json:"description"`
 	Source      string `json:"source"`
 	Engine      string `json:"engine"`
+	// OriginalURL 跳转链接解析前的原始地址（百度、搜狗、Bing 的跟踪链接）
+	OriginalURL string `json:"original_url,omitempty"`
+
+	// 多引擎合并信息：返回该结果的所有引擎及排名，以及倒数排名融合得分
+	Engines  []EngineRank `json:"engines,omitempty"`
+	RRFScore float64      `json:"rrf_score,omitempty"`
+	// Score BM25 相关度与引擎排名混合后的得分（启用 bm25 重排时）
+	Score float64 `json:"score,omitempty"`
+
+	// 学术论文字段（仅学术引擎提供）
+	Authors []string `json:"authors,omitempty"`
+	Venue   string   `json:"venue,omitempty"`
+	Year    int      `json:"year,omitempty"`
+	DOI     string   `json:"doi,omitempty"`
+	PDFURL  string   `json:"pdf_url,omitempty"`
+
+	// 社区讨论字段（Hacker News、Reddit 提供）
+	Points        int    `json:"points,omitempty"`
+	CommentCount  int    `json:"comment_count,omitempty"`
+	DiscussionURL string `json:"discussion_url,omitempty"`
+
+	// 垂直搜索字段（新闻、图片、视频）
+	Thumbnail string `json:"thumbnail,omitempty"`
+	MediaURL  string `json:"media_url,omitempty"`
+	Duration  string `json:"duration,omitempty"`
+
+	// 发布信息：发布者（公众号、媒体等）与发布时间（RFC3339）
+	Publisher   string `json:"publisher,omitempty"`
+	PublishedAt string `json:"published_at,omitempty"`
 }
 
 // SearchEngine 搜索引擎接口
@@ -16,7 +45,52 @@ type SearchEngine interface {
 	// Name 返回引擎名称
 	Name() string
 	// Search 执行搜索
-	Search(ctx context.Context, query string, limit int) ([]SearchResult, error)
+	Search(ctx context.Context, query string, limit int, opts SearchOptions) ([]SearchResult, error)
+	// Capabilities 返回引擎的能力描述（搜索类型、过滤条件、结果数上限、翻页、是否需要浏览器、覆盖地区）
+	Capabilities() Capabilities
+	// QueryDialect 返回引擎原生支持的查询操作符（site:、filetype:、intitle:、短语、排除词、OR）
+	QueryDialect() QueryDialect
+}
+
+// 搜索类型（垂直搜索）
+const (
+	SearchTypeWeb    = "web"
+	SearchTypeNews   = "news"
+	SearchTypeImages = "images"
+	SearchTypeVideos = "videos"
+)
+
+// SearchTypes 所有支持的搜索类型
+var SearchTypes = []string{SearchTypeWeb, SearchTypeNews, SearchTypeImages, SearchTypeVideos}
+
+// webOnly 仅支持网页搜索的引擎使用
+var webOnly = []string{SearchTypeWeb}
+
+// 排序方式
+const (
+	SortRelevance = "relevance"
+	SortDate      = "date"
+)
+
+// SearchOptions 传递给搜索引擎的可选参数，引擎不支持的参数会被忽略
+type SearchOptions struct {
+	// Type 搜索类型: web（默认）、news、images、videos
+	Type string `json:"type,omitempty"`
+	// Sort 排序方式: relevance（默认）或 date
+	Sort string `json:"sort,omitempty"`
+
+	// Freshness 时间范围: day、week、month、year、custom
+	Freshness string `json:"freshness,omitempty"`
+	// DateFrom/DateTo 自定义时间范围（YYYY-MM-DD），配合 freshness=custom 使用
+	DateFrom string `json:"date_from,omitempty"`
+	DateTo   string `json:"date_to,omitempty"`
+	// Region 地区代码（ISO 3166，如 us、de、cn）
+	Region string `json:"region,omitempty"`
+	// Language 语言代码（ISO 639-1，如 en、de、zh）
+	Language string `json:"language,omitempty"`
+
+	// Offset 跳过前 N 条结果，引擎据此计算起始页
+	Offset int `json:"offset,omitempty"`
 }
 
 // SearchRequest 搜索请求
@@ -24,4 +98,49 @@ type SearchRequest struct {
 	Query   string   `json:"query"`
 	Limit   int      `json:"limit,omitempty"`
 	Engines []string `json:"engines,omitempty"`
+	// Fresh 跳过缓存，强制重新搜索
+	Fresh bool `json:"fresh,omitempty"`
+	// MaxPerDomain 每个域名最多保留的结果数，0 表示不限制
+	MaxPerDomain int `json:"max_per_domain,omitempty"`
+	// GroupByDomain 是否在响应中返回按域名的分组
+	GroupByDomain bool `json:"group_by_domain,omitempty"`
+	// DomainRules 本次调用的域名规则，与配置中的全局规则合并
+	DomainRules DomainRules `json:"domain_rules,omitempty"`
+	// TimeoutMS 整次搜索的最长时间（毫秒），到时返回已完成引擎的结果，0 表示只受各引擎超时限制
+	TimeoutMS int `json:"timeout_ms,omitempty"`
+	// EngineOffsets 各引擎已消费的结果数，来自 next_cursor；设置后各引擎按此翻页，而不是使用 Offset
+	EngineOffsets map[string]int `json:"engine_offsets,omitempty"`
+	SearchOptions
+}
+
+// engineOffset 返回引擎本次的起始偏移：游标中没有记录的引擎（例如新启用的备用引擎）从头开始
+func (r SearchRequest) engineOffset(name string) int {
+	if r.EngineOffsets == nil {
+		return r.Offset
+	}
+	return r.EngineOffsets[name]
+}
+
+// SearchResponse 搜索响应
+type SearchResponse struct {
+	Results []SearchResult `json:"results"`
+	// Warnings 搜索过程中的提示，例如引擎无法处理某些过滤条件
+	Warnings []string `json:"warnings,omitempty"`
+	// ServedBy 实际提供结果的引擎，主引擎失败时为备用引擎
+	ServedBy []string `json:"served_by,omitempty"`
+	// Outcomes 每个引擎（包括备用引擎）的执行状态、耗时、结果数和错误
+	Outcomes []EngineOutcome `json:"outcomes,omitempty"`
+	// Filtered 被域名规则过滤掉的结果数
+	Filtered int `json:"filtered,omitempty"`
+	// Dropped 被处理器去掉的结果数（如 filter 去掉的空结果、广告、搜索引擎结果页），不含域名规则
+	Dropped int `json:"dropped,omitempty"`
+	// Groups 按域名的分组，请求 GroupByDomain 时返回
+	Groups []DomainGroup `json:"groups,omitempty"`
+	// Auto 使用 auto 模式时的语言检测和引擎选择结果
+	Auto *AutoDecision `json:"auto,omitempty"`
+	// Cached 结果来自缓存
+	Cached bool `json:"cached,omitempty"`
+	// NextCursor/NextOffset 获取下一页结果的游标和偏移量，没有更多结果时为空
+	NextCursor string `json:"next_cursor,omitempty"`
+	NextOffset int    `json:"next_offset,omitempty"`
 }
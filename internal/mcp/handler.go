@@ -3,6 +3,7 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 
@@ -30,6 +31,28 @@ func NewHandler(cfg *config.Config, em *engine.Manager) *Handler {
 
 // HandleRequest 处理 MCP JSON-RPC 请求
 func (h *Handler) HandleRequest(ctx context.Context, req JSONRPCRequest) JSONRPCResponse {
+	return h.handle(ctx, req, nil)
+}
+
+// HandleStreamingRequest 处理 MCP JSON-RPC 请求，搜索工具调用的中间结果通过 notify 发送
+func (h *Handler) HandleStreamingRequest(ctx context.Context, req JSONRPCRequest, notify func(JSONRPCNotification)) JSONRPCResponse {
+	return h.handle(ctx, req, notify)
+}
+
+// IsStreamable 判断请求是否为要求流式返回的搜索工具调用：客户端需在 _meta.progressToken 中表明要接收进度通知
+func (h *Handler) IsStreamable(req JSONRPCRequest) bool {
+	if req.Method != "tools/call" {
+		return false
+	}
+	callParams, err := decodeCallParams(req.Params)
+	if err != nil || callParams.Name != h.config.GetMCPSearchToolName() {
+		return false
+	}
+	return callParams.Meta != nil && callParams.Meta.ProgressToken != nil
+}
+
+// handle 处理请求，notify 不为空时搜索以流式方式执行
+func (h *Handler) handle(ctx context.Context, req JSONRPCRequest, notify func(JSONRPCNotification)) JSONRPCResponse {
 	log.Printf("📥 MCP Request: method=%s, id=%v", req.Method, req.ID)
 
 	var result interface{}
@@ -44,7 +67,7 @@ func (h *Handler) HandleRequest(ctx context.Context, req JSONRPCRequest) JSONRPC
 	case "tools/list":
 		result = h.handleToolsList()
 	case "tools/call":
-		result, err = h.handleToolsCall(ctx, req.Params)
+		result, err = h.handleToolsCall(ctx, req.Params, notify)
 	case "resources/list":
 		result = ListResourcesResult{Resources: []interface{}{}}
 	case "prompts/list":
@@ -89,21 +112,28 @@ func (h *Handler) handleInitialize() InitializeResult {
 // handleToolsList 处理工具列表请求
 func (h *Handler) handleToolsList() ListToolsResult {
 	return ListToolsResult{
-		Tools: GetTools(h.config),
+		Tools: GetTools(h.config, h.engineManager.EngineInfos()),
 	}
 }
 
-// handleToolsCall 处理工具调用请求
-func (h *Handler) handleToolsCall(ctx context.Context, params interface{}) (*CallToolResult, error) {
-	// 解析参数
+// decodeCallParams 解析工具调用参数
+func decodeCallParams(params interface{}) (CallToolParams, error) {
+	var callParams CallToolParams
 	paramsBytes, err := json.Marshal(params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal params: %w", err)
+		return callParams, fmt.Errorf("failed to marshal params: %w", err)
 	}
-
-	var callParams CallToolParams
 	if err := json.Unmarshal(paramsBytes, &callParams); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal params: %w", err)
+		return callParams, fmt.Errorf("failed to unmarshal params: %w", err)
+	}
+	return callParams, nil
+}
+
+// handleToolsCall 处理工具调用请求
+func (h *Handler) handleToolsCall(ctx context.Context, params interface{}, notify func(JSONRPCNotification)) (*CallToolResult, error) {
+	callParams, err := decodeCallParams(params)
+	if err != nil {
+		return nil, err
 	}
 
 	log.Printf("🔧 Tool call: name=%s, args=%v", callParams.Name, callParams.Arguments)
@@ -113,7 +143,17 @@ func (h *Handler) handleToolsCall(ctx context.Context, params interface{}) (*Cal
 
 	switch callParams.Name {
 	case searchToolName:
-		return h.handleSearch(ctx, callParams.Arguments)
+		var progressToken interface{}
+		if callParams.Meta != nil {
+			progressToken = callParams.Meta.ProgressToken
+		}
+		return h.handleSearch(ctx, callParams.Arguments, notify, progressToken)
+	case InstantAnswerToolName:
+		return h.handleInstantAnswer(ctx, callParams.Arguments)
+	case EngineHealthToolName:
+		return h.handleEngineHealth()
+	case ListEnginesToolName:
+		return h.handleListEngines()
 	default:
 		return &CallToolResult{
 			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("Unknown tool: %s", callParams.Name)}},
@@ -122,55 +162,212 @@ func (h *Handler) handleToolsCall(ctx context.Context, params interface{}) (*Cal
 	}
 }
 
-// handleSearch 处理搜索请求
-func (h *Handler) handleSearch(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
-	// 解析参数
-	query, _ := args["query"].(string)
-	if query == "" {
+// handleSearch 处理搜索请求；notify 不为空时流式搜索，每个引擎完成后发送通知
+func (h *Handler) handleSearch(ctx context.Context, args map[string]interface{}, notify func(JSONRPCNotification), progressToken interface{}) (*CallToolResult, error) {
+	req, err := ParseSearchArgs(args)
+	if err != nil {
 		return &CallToolResult{
-			Content: []ContentItem{{Type: "text", Text: "query is required"}},
+			Content: []ContentItem{{Type: "text", Text: err.Error()}},
+			IsError: true,
+		}, nil
+	}
+
+	// 执行搜索
+	var response *engine.SearchResponse
+	if notify == nil {
+		response, err = h.engineManager.Search(ctx, req)
+	} else {
+		response, err = h.engineManager.SearchStream(ctx, req, searchNotifier(notify, progressToken))
+	}
+
+	if err != nil {
+		return &CallToolResult{
+			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("Search failed: %v", err)}},
+			IsError: true,
+		}, nil
+	}
+
+	// 格式化结果
+	resultJSON, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return &CallToolResult{
+			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("Failed to format results: %v", err)}},
 			IsError: true,
 		}, nil
 	}
 
+	return &CallToolResult{
+		Content: []ContentItem{{Type: "text", Text: string(resultJSON)}},
+	}, nil
+}
+
+// ParseSearchArgs 将搜索工具的参数转换为搜索请求，REST 流式接口使用相同的参数
+func ParseSearchArgs(args map[string]interface{}) (engine.SearchRequest, error) {
+	query, _ := args["query"].(string)
+	if query == "" {
+		return engine.SearchRequest{}, errors.New("query is required")
+	}
+
 	limit := 10
 	if l, ok := args["limit"].(float64); ok {
 		limit = int(l)
 	}
 
-	var engines []string
-	if e, ok := args["engines"].([]interface{}); ok {
-		for _, eng := range e {
-			if s, ok := eng.(string); ok {
-				engines = append(engines, s)
+	engines := stringList(args["engines"])
+
+	var opts engine.SearchOptions
+	opts.Type, _ = args["type"].(string)
+	opts.Sort, _ = args["sort"].(string)
+	opts.Freshness, _ = args["freshness"].(string)
+	opts.DateFrom, _ = args["date_from"].(string)
+	opts.DateTo, _ = args["date_to"].(string)
+	opts.Region, _ = args["region"].(string)
+	opts.Language, _ = args["language"].(string)
+
+	// cursor 优先于 offset，并带有各引擎的偏移
+	if o, ok := args["offset"].(float64); ok {
+		opts.Offset = int(o)
+	}
+	var engineOffsets map[string]int
+	if cursor, _ := args["cursor"].(string); cursor != "" {
+		offset, offsets, err := engine.DecodeCursor(cursor)
+		if err != nil {
+			return engine.SearchRequest{}, err
+		}
+		opts.Offset = offset
+		engineOffsets = offsets
+	}
+
+	if err := opts.Validate(); err != nil {
+		return engine.SearchRequest{}, err
+	}
+
+	fresh, _ := args["fresh"].(bool)
+
+	maxPerDomain := 0
+	if d, ok := args["max_per_domain"].(float64); ok {
+		maxPerDomain = int(d)
+	}
+	if maxPerDomain < 0 {
+		return engine.SearchRequest{}, errors.New("max_per_domain must be >= 0")
+	}
+	groupByDomain, _ := args["group_by_domain"].(bool)
+
+	timeoutMS := 0
+	if t, ok := args["timeout_ms"].(float64); ok {
+		timeoutMS = int(t)
+	}
+	if timeoutMS < 0 {
+		return engine.SearchRequest{}, errors.New("timeout_ms must be >= 0")
+	}
+
+	// 本次调用的域名规则，与配置中的全局规则合并
+	rules := engine.DomainRules{
+		Block: stringList(args["block_domains"]),
+		Allow: stringList(args["allow_domains"]),
+	}
+	if boost, ok := args["boost_domains"].(map[string]interface{}); ok {
+		rules.Boost = make(map[string]float64, len(boost))
+		for pattern, w := range boost {
+			weight, ok := w.(float64)
+			if !ok || weight <= 0 {
+				return engine.SearchRequest{}, fmt.Errorf("boost_domains weight for %s must be a positive number", pattern)
 			}
+			rules.Boost[pattern] = weight
 		}
 	}
 
-	// 执行搜索
-	results, err := h.engineManager.Search(ctx, engine.SearchRequest{
-		Query:   query,
-		Limit:   limit,
-		Engines: engines,
-	})
+	return engine.SearchRequest{
+		Query:         query,
+		Limit:         limit,
+		Engines:       engines,
+		Fresh:         fresh,
+		MaxPerDomain:  maxPerDomain,
+		GroupByDomain: groupByDomain,
+		DomainRules:   rules,
+		TimeoutMS:     timeoutMS,
+		EngineOffsets: engineOffsets,
+		SearchOptions: opts,
+	}, nil
+}
 
+// handleInstantAnswer 处理即时答案请求
+func (h *Handler) handleInstantAnswer(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	query, _ := args["query"].(string)
+	if query == "" {
+		return &CallToolResult{
+			Content: []ContentItem{{Type: "text", Text: "query is required"}},
+			IsError: true,
+		}, nil
+	}
+
+	engines := stringList(args["engines"])
+
+	answer, err := h.engineManager.InstantAnswer(ctx, query, engines)
+	if errors.Is(err, engine.ErrNoInstantAnswer) {
+		// 没有即时答案不算错误，提示调用方改用完整搜索
+		return &CallToolResult{
+			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("No instant answer found for %q; try the search tool instead.", query)}},
+		}, nil
+	}
 	if err != nil {
 		return &CallToolResult{
-			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("Search failed: %v", err)}},
+			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("Instant answer failed: %v", err)}},
 			IsError: true,
 		}, nil
 	}
 
-	// 格式化结果
-	resultJSON, err := json.MarshalIndent(results, "", "  ")
+	answerJSON, err := json.MarshalIndent(answer, "", "  ")
 	if err != nil {
 		return &CallToolResult{
-			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("Failed to format results: %v", err)}},
+			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("Failed to format answer: %v", err)}},
 			IsError: true,
 		}, nil
 	}
 
 	return &CallToolResult{
-		Content: []ContentItem{{Type: "text", Text: string(resultJSON)}},
+		Content: []ContentItem{{Type: "text", Text: string(answerJSON)}},
+	}, nil
+}
+
+// handleEngineHealth 返回各引擎的健康状况和熔断状态
+func (h *Handler) handleEngineHealth() (*CallToolResult, error) {
+	healthJSON, err := json.MarshalIndent(h.engineManager.Health(), "", "  ")
+	if err != nil {
+		return &CallToolResult{
+			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("Failed to format engine health: %v", err)}},
+			IsError: true,
+		}, nil
+	}
+
+	return &CallToolResult{
+		Content: []ContentItem{{Type: "text", Text: string(healthJSON)}},
 	}, nil
 }
+
+// handleListEngines 返回已注册引擎的能力和可用状态
+func (h *Handler) handleListEngines() (*CallToolResult, error) {
+	enginesJSON, err := json.MarshalIndent(h.engineManager.EngineInfos(), "", "  ")
+	if err != nil {
+		return &CallToolResult{
+			Content: []ContentItem{{Type: "text", Text: fmt.Sprintf("Failed to format engine list: %v", err)}},
+			IsError: true,
+		}, nil
+	}
+
+	return &CallToolResult{
+		Content: []ContentItem{{Type: "text", Text: string(enginesJSON)}},
+	}, nil
+}
+
+// stringList 将 JSON 数组参数转换为字符串列表，忽略非字符串元素
+func stringList(v interface{}) []string {
+	items, _ := v.([]interface{})
+	var list []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
@@ -0,0 +1,32 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/config"
+)
+
+func TestIsStreamable(t *testing.T) {
+	h := NewHandler(config.DefaultConfig, nil)
+	tests := []struct {
+		name string
+		req  JSONRPCRequest
+		want bool
+	}{
+		{"search without progress token", JSONRPCRequest{Method: "tools/call", Params: map[string]interface{}{
+			"name": "search", "arguments": map[string]interface{}{"query": "go"},
+		}}, false},
+		{"search with progress token", JSONRPCRequest{Method: "tools/call", Params: map[string]interface{}{
+			"name": "search", "arguments": map[string]interface{}{"query": "go"}, "_meta": map[string]interface{}{"progressToken": 7},
+		}}, true},
+		{"other tool with progress token", JSONRPCRequest{Method: "tools/call", Params: map[string]interface{}{
+			"name": EngineHealthToolName, "_meta": map[string]interface{}{"progressToken": "t"},
+		}}, false},
+		{"tools/list", JSONRPCRequest{Method: "tools/list"}, false},
+	}
+	for _, tt := range tests {
+		if got := h.IsStreamable(tt.req); got != tt.want {
+			t.Errorf("%s: IsStreamable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
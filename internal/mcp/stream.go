@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/engine"
+)
+
+// SearchResultsNotification 流式搜索中某个引擎完成时发送的通知，params 为 engine 事件（引擎、执行情况和结果）
+const SearchResultsNotification = "notifications/search/results"
+
+// searchNotifier 将流式搜索事件转换为 MCP 通知：engine 事件发送结果通知，
+// 调用方提供了 progressToken 时同时发送 notifications/progress；done 和 error 由最终的工具调用响应返回
+func searchNotifier(notify func(JSONRPCNotification), progressToken interface{}) func(engine.SearchEvent) {
+	progress, total := 0, 0
+	return func(event engine.SearchEvent) {
+		switch event.Type {
+		case engine.EventStart:
+			total = len(event.Engines)
+		case engine.EventEngine:
+			notify(JSONRPCNotification{
+				JSONRPC: "2.0",
+				Method:  SearchResultsNotification,
+				Params:  event,
+			})
+
+			if progressToken == nil {
+				return
+			}
+			// 备用引擎也计入进度
+			progress++
+			if progress > total {
+				total = progress
+			}
+			notify(JSONRPCNotification{
+				JSONRPC: "2.0",
+				Method:  "notifications/progress",
+				Params: ProgressParams{
+					ProgressToken: progressToken,
+					Progress:      progress,
+					Total:         total,
+					Message:       fmt.Sprintf("%s: %s, %d result(s)", event.Engine, event.Outcome.Status, len(event.Results)),
+				},
+			})
+		}
+	}
+}
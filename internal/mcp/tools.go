@@ -1,40 +1,173 @@
 package mcp
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/cliffyan/go-web-search-mcp/internal/config"
+	"github.com/cliffyan/go-web-search-mcp/internal/engine"
 )
 
-// GetTools 获取所有 MCP 工具定义
-func GetTools(cfg *config.Config) []Tool {
-	// 构建引擎枚举列表
-	engineEnum := config.ValidEngines
+// InstantAnswerToolName 即时答案工具名称
+const InstantAnswerToolName = "instant_answer"
+
+// EngineHealthToolName 引擎健康状况工具名称
+const EngineHealthToolName = "engine_health"
+
+// ListEnginesToolName 引擎能力列表工具名称
+const ListEnginesToolName = "list_engines"
+
+// GetTools 获取所有 MCP 工具定义，引擎枚举和描述由已注册引擎的能力生成
+func GetTools(cfg *config.Config, engines []engine.EngineInfo) []Tool {
+	// 构建引擎枚举列表，auto 按查询语言选择引擎，只列出允许使用的引擎
+	engineEnum := []string{config.AutoEngine}
+	for _, info := range engines {
+		if info.Allowed {
+			engineEnum = append(engineEnum, info.Name)
+		}
+	}
+	// 即时答案只列出能提供即时答案的引擎
+	instantEnum := enginesWith(engines, func(info engine.EngineInfo) bool {
+		return info.InstantAnswer
+	})
 
 	return []Tool{
 		{
 			Name:        cfg.GetMCPSearchToolName(),
-			Description: cfg.GetMCPSearchToolDescription(),
+			Description: searchDescription(cfg, engines),
 			InputSchema: InputSchema{
 				Type: "object",
 				Properties: map[string]Property{
 					"query": {
 						Type:        "string",
-						Description: "The search query string",
+						Description: "The search query string. Supports operators: site:example.com, -site:example.com, filetype:pdf, intitle:word, \"exact phrase\", -excluded, a OR b. Operators an engine cannot handle natively are applied as a post-filter.",
 					},
 					"limit": {
 						Type:        "number",
-						Description: "Maximum number of results to return (default: 10)",
+						Description: "Maximum number of results to return after merging all engines (default: 10)",
 						Default:     10,
 					},
+					"offset": {
+						Type:        "number",
+						Description: "Number of results to skip for pagination (default: 0). Each engine starts from the matching page.",
+						Default:     0,
+					},
+					"cursor": {
+						Type:        "string",
+						Description: "Opaque pagination cursor returned as next_cursor by a previous call with the same query; takes precedence over offset",
+					},
 					"engines": {
 						Type:        "array",
-						Description: "Search engines to use. Available: bing, baidu, duckduckgo, google. Default uses the configured default engine.",
+						Description: enginesDescription(engines),
+						Items:       &Items{Type: "string", Enum: engineEnum},
+					},
+					"type": {
+						Type:        "string",
+						Description: typeDescription(engines),
+						Enum:        engine.SearchTypes,
+						Default:     "web",
+					},
+					"sort": {
+						Type:        "string",
+						Description: sortDescription(engines),
+						Enum:        []string{"relevance", "date"},
+					},
+					"freshness": {
+						Type:        "string",
+						Description: "Only return results published within the given period: day, week, month, year, or custom (requires date_from and/or date_to). Engines that cannot honor it are reported in warnings.",
+						Enum:        engine.FreshnessValues,
+					},
+					"date_from": {
+						Type:        "string",
+						Description: "Start date (YYYY-MM-DD) for a custom time range; implies freshness=custom",
+					},
+					"date_to": {
+						Type:        "string",
+						Description: "End date (YYYY-MM-DD, inclusive) for a custom time range; implies freshness=custom",
+					},
+					"region": {
+						Type:        "string",
+						Description: "Country/region code, e.g. us, de, cn (Bing cc, Google gl, DuckDuckGo kl)",
+					},
+					"language": {
+						Type:        "string",
+						Description: "Language code, e.g. en, de, zh (Bing setlang, Google hl/lr, DuckDuckGo kl, Accept-Language)",
+					},
+					"max_per_domain": {
+						Type:        "number",
+						Description: "Maximum results kept per domain (subdomains count as the same site) so results cover more distinct sources; 0 means no cap (default: 0)",
+						Default:     0,
+					},
+					"group_by_domain": {
+						Type:        "boolean",
+						Description: "Also return groups: each domain with its result count and 1-based positions in results (default: false)",
+						Default:     false,
+					},
+					"block_domains": {
+						Type:        "array",
+						Description: "Domains to drop from results, added to the server's block list. example.com matches the domain and its subdomains, *.example.com only subdomains, other * patterns are wildcards",
 						Items:       &Items{Type: "string"},
-						Enum:        engineEnum,
+					},
+					"allow_domains": {
+						Type:        "array",
+						Description: "Only keep results from these domains (same matching rules as block_domains); replaces the server's allow list for this call",
+						Items:       &Items{Type: "string"},
+					},
+					"boost_domains": {
+						Type:                 "object",
+						Description:          "Ranking weight per domain pattern, e.g. {\"go.dev\": 2, \"*.csdn.net\": 0.5}; >1 boosts, <1 demotes",
+						AdditionalProperties: &Items{Type: "number"},
+					},
+					"timeout_ms": {
+						Type:        "number",
+						Description: "Overall time budget in milliseconds. When it runs out, results from engines that already answered are returned and the rest are reported as timeout in outcomes; 0 means only the per-engine deadlines apply (default: 0)",
+						Default:     0,
+					},
+					"fresh": {
+						Type:        "boolean",
+						Description: "Bypass the result cache and search again (default: false). Cached responses are marked with cached: true.",
+						Default:     false,
 					},
 				},
 				Required: []string{"query"},
 			},
 		},
+		{
+			Name:        InstantAnswerToolName,
+			Description: "Get a quick instant answer for definitional or factual queries (abstract, source, related topics and infobox). Much cheaper and faster than a full search; try it first for \"what is X\" style questions.",
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"query": {
+						Type:        "string",
+						Description: "The question or entity to look up",
+					},
+					"engines": {
+						Type:        "array",
+						Description: instantAnswerDescription(instantEnum),
+						Items:       &Items{Type: "string", Enum: instantEnum},
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+		{
+			Name:        EngineHealthToolName,
+			Description: "Report per-engine health: success/failure/captcha counts, last error and circuit breaker state. Engines whose circuit is open are skipped (or routed to their fallbacks) until the cool-down ends.",
+			InputSchema: InputSchema{
+				Type:       "object",
+				Properties: map[string]Property{},
+			},
+		},
+		{
+			Name:        ListEnginesToolName,
+			Description: "List the available search engines with their capabilities: category, supported verticals and filters, max results per search, pagination, whether a local Chrome is required, region focus, whether the engine is allowed and the default, and whether it provides instant answers.",
+			InputSchema: InputSchema{
+				Type:       "object",
+				Properties: map[string]Property{},
+			},
+		},
 		// TODO: 后续添加更多工具
 		// {
 		// 	Name:        "fetchArticle",
@@ -43,3 +176,117 @@ func GetTools(cfg *config.Config) []Tool {
 		// },
 	}
 }
+
+// categoryNotes 各类引擎结果中额外包含的字段
+var categoryNotes = map[string]string{
+	engine.CategoryAcademic:   "results include authors, venue, year, doi, pdf_url",
+	engine.CategoryDiscussion: "results include points, comment_count, published_at, discussion_url",
+}
+
+// engineGroups 按类别列出允许使用的引擎，并注明区域、浏览器要求和结果字段；没有可用引擎时返回 none
+func engineGroups(engines []engine.EngineInfo) string {
+	var groups []string
+	for _, category := range []string{engine.CategoryWeb, engine.CategoryAcademic, engine.CategoryDiscussion} {
+		var names []string
+		for _, info := range engines {
+			if !info.Allowed || info.Category != category {
+				continue
+			}
+			var notes []string
+			if info.RegionFocus != engine.RegionGlobal {
+				notes = append(notes, info.RegionFocus)
+			}
+			if info.RequiresBrowser {
+				notes = append(notes, "needs Chrome")
+			}
+			name := info.Name
+			if len(notes) > 0 {
+				name += " (" + strings.Join(notes, ", ") + ")"
+			}
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			continue
+		}
+		group := category + ": " + strings.Join(names, ", ")
+		if note, ok := categoryNotes[category]; ok {
+			group += " (" + note + ")"
+		}
+		groups = append(groups, group)
+	}
+
+	if len(groups) == 0 {
+		return "none"
+	}
+	return strings.Join(groups, "; ")
+}
+
+// searchDescription 在配置的工具描述后附上可用的引擎和默认引擎
+func searchDescription(cfg *config.Config, engines []engine.EngineInfo) string {
+	desc := strings.TrimSpace(cfg.GetMCPSearchToolDescription())
+	desc += fmt.Sprintf(" Engines: %s.", engineGroups(engines))
+	for _, info := range engines {
+		if info.Default && info.Allowed {
+			desc += fmt.Sprintf(" Default engine: %s.", info.Name)
+		}
+	}
+	return desc
+}
+
+// enginesDescription 说明 engines 参数，列出允许使用的引擎
+func enginesDescription(engines []engine.EngineInfo) string {
+	return fmt.Sprintf("Search engines to use. Available: %s. Use auto to pick engines by the query's script/language (or the language argument); the choice is reported in the response's auto field. Call %s for each engine's capabilities. Default uses the configured default engine.", engineGroups(engines), ListEnginesToolName)
+}
+
+// instantAnswerDescription 说明即时答案的 engines 参数，默认引擎只列出可用的
+func instantAnswerDescription(available []string) string {
+	if len(available) == 0 {
+		return "Engines to try in order until one returns an answer. No available engine provides instant answers."
+	}
+	var defaults []string
+	for _, name := range engine.DefaultInstantAnswerEngines {
+		if slices.Contains(available, name) {
+			defaults = append(defaults, name)
+		}
+	}
+	desc := fmt.Sprintf("Engines to try in order until one returns an answer. Available: %s (duckduckgo uses its Instant Answer API, the others extract featured snippets from the result page).", strings.Join(available, ", "))
+	if len(defaults) > 0 {
+		desc += fmt.Sprintf(" Default: %s.", strings.Join(defaults, ", "))
+	}
+	return desc
+}
+
+// typeDescription 说明搜索类型及支持 news/images/videos 的引擎
+func typeDescription(engines []engine.EngineInfo) string {
+	desc := "Search vertical: web (default), news, images or videos."
+	if names := enginesWith(engines, func(info engine.EngineInfo) bool {
+		return len(info.Verticals) > 1
+	}); len(names) > 0 {
+		desc += fmt.Sprintf(" news/images/videos are supported by %s;", strings.Join(names, ", "))
+	} else {
+		desc += " No available engine supports news/images/videos;"
+	}
+	return desc + " results may include thumbnail, media_url, duration, publisher and published_at."
+}
+
+// sortDescription 说明排序方式及支持按日期排序的引擎
+func sortDescription(engines []engine.EngineInfo) string {
+	desc := "Result ordering: relevance (default) or date."
+	if names := enginesWith(engines, func(info engine.EngineInfo) bool {
+		return slices.Contains(info.Filters, engine.FilterSort)
+	}); len(names) > 0 {
+		desc += fmt.Sprintf(" Honored by engines that support it (%s); others report it in warnings.", strings.Join(names, ", "))
+	}
+	return desc
+}
+
+// enginesWith 返回满足条件且允许使用的引擎名称
+func enginesWith(engines []engine.EngineInfo, match func(engine.EngineInfo) bool) []string {
+	var names []string
+	for _, info := range engines {
+		if info.Allowed && match(info) {
+			names = append(names, info.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cliffyan/go-web-search-mcp/internal/config"
+	"github.com/cliffyan/go-web-search-mcp/internal/engine"
+)
+
+// 数组参数的可选值必须放在 items 上，放在数组本身表示整个数组等于某个字符串
+func TestArrayEnumsOnItems(t *testing.T) {
+	infos := []engine.EngineInfo{{Name: "bing", Allowed: true}}
+	for _, tool := range GetTools(config.DefaultConfig, infos) {
+		for name, prop := range tool.InputSchema.Properties {
+			if prop.Type == "array" && len(prop.Enum) > 0 {
+				t.Errorf("%s.%s: enum set on the array instead of items", tool.Name, name)
+			}
+		}
+	}
+
+	engines := GetTools(config.DefaultConfig, infos)[0].InputSchema.Properties["engines"]
+	if engines.Items == nil || len(engines.Items.Enum) == 0 {
+		t.Fatal("engines items have no enum")
+	}
+}
+
+// 工具描述和即时答案引擎只列出已注册且允许使用的引擎
+func TestToolsFromRegisteredEngines(t *testing.T) {
+	infos := []engine.EngineInfo{
+		{Name: "bing", Allowed: true, Default: true, InstantAnswer: true, Capabilities: engine.Capabilities{Category: engine.CategoryWeb, RegionFocus: engine.RegionGlobal}},
+		{Name: "duckduckgo", Allowed: false, InstantAnswer: true, Capabilities: engine.Capabilities{Category: engine.CategoryWeb, RegionFocus: engine.RegionGlobal}},
+		{Name: "arxiv", Allowed: true, Capabilities: engine.Capabilities{Category: engine.CategoryAcademic, RegionFocus: engine.RegionGlobal}},
+	}
+	tools := GetTools(config.DefaultConfig, infos)
+
+	search := tools[0]
+	for _, want := range []string{"bing", "arxiv", "Default engine: bing."} {
+		if !strings.Contains(search.Description, want) {
+			t.Errorf("search description missing %q: %s", want, search.Description)
+		}
+	}
+	if strings.Contains(search.Description, "duckduckgo") {
+		t.Errorf("search description lists a disallowed engine: %s", search.Description)
+	}
+
+	var instant Tool
+	for _, tool := range tools {
+		if tool.Name == InstantAnswerToolName {
+			instant = tool
+		}
+	}
+	engines := instant.InputSchema.Properties["engines"]
+	if engines.Items == nil || !slices.Equal(engines.Items.Enum, []string{"bing"}) {
+		t.Fatalf("instant_answer engines = %+v, want [bing]", engines.Items)
+	}
+	if !strings.Contains(engines.Description, "Default: bing.") {
+		t.Errorf("instant_answer description = %q, want only available defaults", engines.Description)
+	}
+}
@@ -60,20 +60,45 @@ type Property struct {
 	Default     any      `json:"default,omitempty"`
 	Items       *Items   `json:"items,omitempty"`
 	Enum        []string `json:"enum,omitempty"`
+	// AdditionalProperties object 类型参数的值类型
+	AdditionalProperties *Items `json:"additionalProperties,omitempty"`
 }
 
 type Items struct {
 	Type string `json:"type"`
+	// Enum 数组元素的可选值
+	Enum []string `json:"enum,omitempty"`
 }
 
 type ListToolsResult struct {
 	Tools []Tool `json:"tools"`
 }
 
+// JSONRPCNotification JSON-RPC 通知（没有 id，不需要响应）
+type JSONRPCNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
 // 工具调用参数
 type CallToolParams struct {
 	Name      string                 `json:"name"`
 	Arguments map[string]interface{} `json:"arguments"`
+	Meta      *RequestMeta           `json:"_meta,omitempty"`
+}
+
+// RequestMeta 请求元数据，progressToken 用于关联进度通知
+type RequestMeta struct {
+	ProgressToken interface{} `json:"progressToken,omitempty"`
+}
+
+// ProgressParams notifications/progress 通知参数
+type ProgressParams struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      int         `json:"progress"`
+	Total         int         `json:"total,omitempty"`
+	Message       string      `json:"message,omitempty"`
 }
 
 // 工具调用结果
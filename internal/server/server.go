@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -51,6 +55,9 @@ func (s *Server) Start() error {
 	// SSE 端点（兼容旧客户端）
 	mux.HandleFunc("/sse", s.handleSSE)
 
+	// 流式搜索 REST 端点（NDJSON）
+	mux.HandleFunc("/search/stream", s.handleSearchStream)
+
 	// 健康检查
 	mux.HandleFunc("/health", s.handleHealth)
 
@@ -70,6 +77,7 @@ func (s *Server) Start() error {
 	log.Printf("🚀 Starting MCP HTTP server on %s", addr)
 	log.Printf("📡 MCP endpoint: http://%s/mcp", addr)
 	log.Printf("📡 SSE endpoint: http://%s/sse", addr)
+	log.Printf("🌊 Search stream endpoint: http://%s/search/stream", addr)
 	log.Printf("❤️ Health check: http://%s/health", addr)
 
 	return http.ListenAndServe(addr, handler)
@@ -116,6 +124,12 @@ func (s *Server) handleMCPPost(w http.ResponseWriter, r *http.Request) {
 		log.Printf("📝 Created new session: %s", sessionID)
 	}
 
+	// 客户端接受 SSE 且在 _meta.progressToken 中要求进度通知时，搜索工具调用以流式返回
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") && s.mcpHandler.IsStreamable(req) {
+		s.handleMCPStream(w, r, req)
+		return
+	}
+
 	// 处理请求
 	ctx := r.Context()
 	resp := s.mcpHandler.HandleRequest(ctx, req)
@@ -133,6 +147,36 @@ func (s *Server) handleMCPPost(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleMCPStream 以 SSE 返回搜索工具调用：每个引擎完成时发送通知，最后发送 JSON-RPC 响应
+func (s *Server) handleMCPStream(w http.ResponseWriter, r *http.Request, req mcp.JSONRPCRequest) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(message interface{}) {
+		data, err := json.Marshal(message)
+		if err != nil {
+			log.Printf("❌ Failed to encode SSE message: %v", err)
+			return
+		}
+		fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+		flusher.Flush()
+	}
+
+	resp := s.mcpHandler.HandleStreamingRequest(r.Context(), req, func(n mcp.JSONRPCNotification) {
+		send(n)
+	})
+	send(resp)
+}
+
 // handleMCPGet 处理 MCP GET 请求（SSE 流）
 func (s *Server) handleMCPGet(w http.ResponseWriter, r *http.Request) {
 	sessionID := r.Header.Get("mcp-session-id")
@@ -251,6 +295,112 @@ func (s *Server) handleSSE(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleSearchStream 流式搜索端点：每行一个 JSON 事件（NDJSON），引擎完成时立即输出
+// POST 请求体为 search 工具的参数；GET 使用同名查询参数，列表参数用逗号分隔，boost_domains 写作 domain:weight
+func (s *Server) handleSearchStream(w http.ResponseWriter, r *http.Request) {
+	var args map[string]interface{}
+	switch r.Method {
+	case http.MethodGet:
+		var err error
+		if args, err = queryArgs(r.URL.Query()); err != nil {
+			s.sendHTTPError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			s.sendHTTPError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+			return
+		}
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, err := mcp.ParseSearchArgs(args)
+	if err != nil {
+		s.sendHTTPError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	// 搜索失败时已输出 error 事件
+	s.engineManager.SearchStream(r.Context(), req, func(event engine.SearchEvent) {
+		if err := enc.Encode(event); err != nil {
+			log.Printf("❌ Failed to write stream event: %v", err)
+			return
+		}
+		flusher.Flush()
+	})
+}
+
+// 查询参数的类型，未列出的参数按字符串处理
+var (
+	listArgs   = []string{"engines", "block_domains", "allow_domains"}
+	numberArgs = []string{"limit", "offset", "max_per_domain", "timeout_ms"}
+	boolArgs   = []string{"fresh", "group_by_domain"}
+)
+
+// queryArgs 将 URL 查询参数转换为与 search 工具相同的参数
+func queryArgs(values url.Values) (map[string]interface{}, error) {
+	args := make(map[string]interface{}, len(values))
+	for key, vals := range values {
+		value := vals[len(vals)-1]
+		switch {
+		case slices.Contains(listArgs, key):
+			// 支持逗号分隔和重复参数两种写法
+			var list []interface{}
+			for _, v := range vals {
+				for _, item := range strings.Split(v, ",") {
+					if item = strings.TrimSpace(item); item != "" {
+						list = append(list, item)
+					}
+				}
+			}
+			args[key] = list
+		case slices.Contains(numberArgs, key):
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a number", key)
+			}
+			args[key] = n
+		case slices.Contains(boolArgs, key):
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a boolean", key)
+			}
+			args[key] = b
+		case key == "boost_domains":
+			boost := make(map[string]interface{})
+			for _, item := range strings.Split(value, ",") {
+				pattern, weight, ok := strings.Cut(strings.TrimSpace(item), ":")
+				w, err := strconv.ParseFloat(weight, 64)
+				if !ok || err != nil {
+					return nil, fmt.Errorf("boost_domains must be domain:weight pairs, got %q", item)
+				}
+				boost[pattern] = w
+			}
+			args[key] = boost
+		default:
+			args[key] = value
+		}
+	}
+	return args, nil
+}
+
 // handleHealth 健康检查端点
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -259,9 +409,18 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 		"service": s.config.GetMCPServerName(),
 		"version": s.config.GetMCPServerVersion(),
 		"engines": s.engineManager.GetEngineNames(),
+		// 各引擎的成功/失败统计和熔断状态
+		"engine_health": s.engineManager.Health(),
 	})
 }
 
+// sendHTTPError 发送 REST 端点的 JSON 错误响应
+func (s *Server) sendHTTPError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
 // sendError 发送错误响应
 func (s *Server) sendError(w http.ResponseWriter, id interface{}, code int, message string) {
 	w.Header().Set("Content-Type", "application/json")